@@ -3,6 +3,9 @@ terraform {
     aws = {
       source = "hashicorp/aws"
     }
+    random = {
+      source = "hashicorp/random"
+    }
   }
 }
 
@@ -25,6 +28,52 @@ resource "aws_dynamodb_table" "basic-dynamodb-table" {
     name = "ID"
     type = "S"
   }
+
+  attribute {
+    name = "dueKey"
+    type = "S"
+  }
+
+  attribute {
+    name = "dueAt"
+    type = "S"
+  }
+
+  attribute {
+    name = "listId"
+    type = "S"
+  }
+
+  global_secondary_index {
+    name            = "due-index"
+    hash_key        = "dueKey"
+    range_key       = "dueAt"
+    read_capacity   = 5
+    write_capacity  = 5
+    projection_type = "ALL"
+  }
+
+  global_secondary_index {
+    name            = "list-index"
+    hash_key        = "listId"
+    range_key       = "ID"
+    read_capacity   = 5
+    write_capacity  = 5
+    projection_type = "ALL"
+  }
+}
+
+resource "aws_dynamodb_table" "lists-dynamodb-table" {
+  name           = "todo-lists"
+  billing_mode   = "PROVISIONED"
+  read_capacity  = 5
+  write_capacity = 5
+  hash_key       = "ID"
+
+  attribute {
+    name = "ID"
+    type = "S"
+  }
 }
 
 resource "aws_iam_policy" "todo-policy" {
@@ -37,12 +86,16 @@ resource "aws_iam_policy" "todo-policy" {
         Effect = "Allow"
         Action = [
           "dynamodb:PutItem",
+          "dynamodb:UpdateItem",
           "dynamodb:DeleteItem",
           "dynamodb:GetItem",
           "dynamodb:Scan",
+          "dynamodb:Query",
         ]
         Resource = [
-          aws_dynamodb_table.basic-dynamodb-table.arn
+          aws_dynamodb_table.basic-dynamodb-table.arn,
+          "${aws_dynamodb_table.basic-dynamodb-table.arn}/index/*",
+          aws_dynamodb_table.lists-dynamodb-table.arn
         ]
       },
       {
@@ -77,6 +130,11 @@ resource "aws_iam_role_policy_attachment" "attach-policy" {
   policy_arn = aws_iam_policy.todo-policy.arn
 }
 
+resource "random_password" "cursor-secret" {
+  length  = 32
+  special = false
+}
+
 resource "aws_lambda_function" "todo-lambda" {
   filename         = "function.zip"
   function_name    = "todo-lambda"
@@ -85,6 +143,15 @@ resource "aws_lambda_function" "todo-lambda" {
   source_code_hash = filebase64sha256("function.zip")
 
   runtime = "go1.x"
+
+  environment {
+    variables = {
+      CURSOR_SECRET             = random_password.cursor-secret.result
+      TODO_DYNAMODB_TABLE       = aws_dynamodb_table.basic-dynamodb-table.name
+      TODO_DYNAMODB_LISTS_TABLE = aws_dynamodb_table.lists-dynamodb-table.name
+      TODO_METRICS_NAMESPACE    = "TodoAPI"
+    }
+  }
 }
 
 resource "aws_api_gateway_rest_api" "todo-api" {
@@ -127,6 +194,30 @@ resource "aws_api_gateway_rest_api" "todo-api" {
         }
       },
       "/todo-api/{id}" : {
+        "patch" : {
+          "parameters" : [
+            {
+              "name" : "id",
+              "in" : "path",
+              "required" : true,
+              "schema" : {
+                "type" : "string"
+              }
+            }
+          ],
+          "x-amazon-apigateway-integration" : {
+            "httpMethod" : "POST",
+            "uri" : "arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/${aws_lambda_function.todo-lambda.arn}/invocations",
+            "responses" : {
+              "default" : {
+                "statusCode" : "200"
+              }
+            },
+            "passthroughBehavior" : "when_no_match",
+            "contentHandling" : "CONVERT_TO_TEXT",
+            "type" : "aws_proxy"
+          }
+        },
         "get" : {
           "parameters" : [
             {
@@ -151,6 +242,30 @@ resource "aws_api_gateway_rest_api" "todo-api" {
             "type" : "aws_proxy"
           }
         },
+        "put" : {
+          "parameters" : [
+            {
+              "name" : "id",
+              "in" : "path",
+              "required" : true,
+              "schema" : {
+                "type" : "string"
+              }
+            }
+          ],
+          "x-amazon-apigateway-integration" : {
+            "httpMethod" : "POST",
+            "uri" : "arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/${aws_lambda_function.todo-lambda.arn}/invocations",
+            "responses" : {
+              "default" : {
+                "statusCode" : "200"
+              }
+            },
+            "passthroughBehavior" : "when_no_match",
+            "contentHandling" : "CONVERT_TO_TEXT",
+            "type" : "aws_proxy"
+          }
+        },
         "delete" : {
           "parameters" : [
             {
@@ -175,55 +290,605 @@ resource "aws_api_gateway_rest_api" "todo-api" {
             "type" : "aws_proxy"
           }
         }
-      }
-    }
-  })
-
-  name = "todo-api"
-
-  endpoint_configuration {
-    types = ["REGIONAL"]
-  }
-}
-
-resource "aws_api_gateway_deployment" "todo-deployment" {
-  rest_api_id = aws_api_gateway_rest_api.todo-api.id
-
-  triggers = {
-    redeployment = sha1(jsonencode(aws_api_gateway_rest_api.todo-api.body))
-  }
-
-  lifecycle {
-    create_before_destroy = true
-  }
-}
-
-resource "aws_api_gateway_stage" "todo-stage" {
-  deployment_id = aws_api_gateway_deployment.todo-deployment.id
-  rest_api_id   = aws_api_gateway_rest_api.todo-api.id
-  stage_name    = "prod"
-}
-
-resource "aws_lambda_permission" "todo-gw-lambda" {
-  action        = "lambda:InvokeFunction"
-  function_name = aws_lambda_function.todo-lambda.arn
-  principal     = "apigateway.amazonaws.com"
-
-  source_arn = "arn:aws:execute-api:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:${aws_api_gateway_rest_api.todo-api.id}/*/*/todo-api"
-}
-
-resource "aws_lambda_permission" "todo-gw-lambda-get" {
-  action        = "lambda:InvokeFunction"
-  function_name = aws_lambda_function.todo-lambda.arn
-  principal     = "apigateway.amazonaws.com"
-
-  source_arn = "arn:aws:execute-api:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:${aws_api_gateway_rest_api.todo-api.id}/*/GET/*"
-}
-
-resource "aws_lambda_permission" "todo-gw-lambda-delete" {
-  action        = "lambda:InvokeFunction"
-  function_name = aws_lambda_function.todo-lambda.arn
-  principal     = "apigateway.amazonaws.com"
-
-  source_arn = "arn:aws:execute-api:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:${aws_api_gateway_rest_api.todo-api.id}/*/DELETE/*"
-}
\ No newline at end of file
+      },
+      "/todo-api/{id}/complete" : {
+        "post" : {
+          "parameters" : [
+            {
+              "name" : "id",
+              "in" : "path",
+              "required" : true,
+              "schema" : {
+                "type" : "string"
+              }
+            }
+          ],
+          "x-amazon-apigateway-integration" : {
+            "httpMethod" : "POST",
+            "uri" : "arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/${aws_lambda_function.todo-lambda.arn}/invocations",
+            "responses" : {
+              "default" : {
+                "statusCode" : "200"
+              }
+            },
+            "passthroughBehavior" : "when_no_match",
+            "contentHandling" : "CONVERT_TO_TEXT",
+            "type" : "aws_proxy"
+          }
+        }
+      },
+      "/todo-api/{id}/reopen" : {
+        "post" : {
+          "parameters" : [
+            {
+              "name" : "id",
+              "in" : "path",
+              "required" : true,
+              "schema" : {
+                "type" : "string"
+              }
+            }
+          ],
+          "x-amazon-apigateway-integration" : {
+            "httpMethod" : "POST",
+            "uri" : "arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/${aws_lambda_function.todo-lambda.arn}/invocations",
+            "responses" : {
+              "default" : {
+                "statusCode" : "200"
+              }
+            },
+            "passthroughBehavior" : "when_no_match",
+            "contentHandling" : "CONVERT_TO_TEXT",
+            "type" : "aws_proxy"
+          }
+        }
+      },
+      "/todo-api/overdue" : {
+        "get" : {
+          "x-amazon-apigateway-integration" : {
+            "httpMethod" : "POST",
+            "uri" : "arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/${aws_lambda_function.todo-lambda.arn}/invocations",
+            "responses" : {
+              "default" : {
+                "statusCode" : "200"
+              }
+            },
+            "passthroughBehavior" : "when_no_match",
+            "contentHandling" : "CONVERT_TO_TEXT",
+            "type" : "aws_proxy"
+          }
+        }
+      },
+      "/todo-api/upcoming" : {
+        "get" : {
+          "x-amazon-apigateway-integration" : {
+            "httpMethod" : "POST",
+            "uri" : "arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/${aws_lambda_function.todo-lambda.arn}/invocations",
+            "responses" : {
+              "default" : {
+                "statusCode" : "200"
+              }
+            },
+            "passthroughBehavior" : "when_no_match",
+            "contentHandling" : "CONVERT_TO_TEXT",
+            "type" : "aws_proxy"
+          }
+        }
+      },
+      "/todo-api/tags" : {
+        "get" : {
+          "x-amazon-apigateway-integration" : {
+            "httpMethod" : "POST",
+            "uri" : "arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/${aws_lambda_function.todo-lambda.arn}/invocations",
+            "responses" : {
+              "default" : {
+                "statusCode" : "200"
+              }
+            },
+            "passthroughBehavior" : "when_no_match",
+            "contentHandling" : "CONVERT_TO_TEXT",
+            "type" : "aws_proxy"
+          }
+        }
+      },
+      "/todo-api/tags/merge" : {
+        "post" : {
+          "x-amazon-apigateway-integration" : {
+            "httpMethod" : "POST",
+            "uri" : "arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/${aws_lambda_function.todo-lambda.arn}/invocations",
+            "responses" : {
+              "default" : {
+                "statusCode" : "200"
+              }
+            },
+            "passthroughBehavior" : "when_no_match",
+            "contentHandling" : "CONVERT_TO_TEXT",
+            "type" : "aws_proxy"
+          }
+        }
+      },
+      "/todo-api/tags/{tag}/rename" : {
+        "post" : {
+          "parameters" : [
+            {
+              "name" : "tag",
+              "in" : "path",
+              "required" : true,
+              "schema" : {
+                "type" : "string"
+              }
+            }
+          ],
+          "x-amazon-apigateway-integration" : {
+            "httpMethod" : "POST",
+            "uri" : "arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/${aws_lambda_function.todo-lambda.arn}/invocations",
+            "responses" : {
+              "default" : {
+                "statusCode" : "200"
+              }
+            },
+            "passthroughBehavior" : "when_no_match",
+            "contentHandling" : "CONVERT_TO_TEXT",
+            "type" : "aws_proxy"
+          }
+        }
+      },
+      "/todo-api/next" : {
+        "get" : {
+          "x-amazon-apigateway-integration" : {
+            "httpMethod" : "POST",
+            "uri" : "arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/${aws_lambda_function.todo-lambda.arn}/invocations",
+            "responses" : {
+              "default" : {
+                "statusCode" : "200"
+              }
+            },
+            "passthroughBehavior" : "when_no_match",
+            "contentHandling" : "CONVERT_TO_TEXT",
+            "type" : "aws_proxy"
+          }
+        }
+      },
+      "/todo-api/lists" : {
+        "post" : {
+          "x-amazon-apigateway-integration" : {
+            "httpMethod" : "POST",
+            "uri" : "arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/${aws_lambda_function.todo-lambda.arn}/invocations",
+            "responses" : {
+              "default" : {
+                "statusCode" : "200"
+              }
+            },
+            "passthroughBehavior" : "when_no_match",
+            "contentHandling" : "CONVERT_TO_TEXT",
+            "type" : "aws_proxy"
+          }
+        },
+        "get" : {
+          "x-amazon-apigateway-integration" : {
+            "httpMethod" : "POST",
+            "uri" : "arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/${aws_lambda_function.todo-lambda.arn}/invocations",
+            "responses" : {
+              "default" : {
+                "statusCode" : "200"
+              }
+            },
+            "passthroughBehavior" : "when_no_match",
+            "contentHandling" : "CONVERT_TO_TEXT",
+            "type" : "aws_proxy"
+          }
+        }
+      },
+      "/todo-api/lists/{listId}" : {
+        "delete" : {
+          "parameters" : [
+            {
+              "name" : "listId",
+              "in" : "path",
+              "required" : true,
+              "schema" : {
+                "type" : "string"
+              }
+            }
+          ],
+          "x-amazon-apigateway-integration" : {
+            "httpMethod" : "POST",
+            "uri" : "arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/${aws_lambda_function.todo-lambda.arn}/invocations",
+            "responses" : {
+              "default" : {
+                "statusCode" : "200"
+              }
+            },
+            "passthroughBehavior" : "when_no_match",
+            "contentHandling" : "CONVERT_TO_TEXT",
+            "type" : "aws_proxy"
+          }
+        },
+        "put" : {
+          "parameters" : [
+            {
+              "name" : "listId",
+              "in" : "path",
+              "required" : true,
+              "schema" : {
+                "type" : "string"
+              }
+            }
+          ],
+          "x-amazon-apigateway-integration" : {
+            "httpMethod" : "POST",
+            "uri" : "arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/${aws_lambda_function.todo-lambda.arn}/invocations",
+            "responses" : {
+              "default" : {
+                "statusCode" : "200"
+              }
+            },
+            "passthroughBehavior" : "when_no_match",
+            "contentHandling" : "CONVERT_TO_TEXT",
+            "type" : "aws_proxy"
+          }
+        },
+        "get" : {
+          "parameters" : [
+            {
+              "name" : "listId",
+              "in" : "path",
+              "required" : true,
+              "schema" : {
+                "type" : "string"
+              }
+            }
+          ],
+          "x-amazon-apigateway-integration" : {
+            "httpMethod" : "POST",
+            "uri" : "arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/${aws_lambda_function.todo-lambda.arn}/invocations",
+            "responses" : {
+              "default" : {
+                "statusCode" : "200"
+              }
+            },
+            "passthroughBehavior" : "when_no_match",
+            "contentHandling" : "CONVERT_TO_TEXT",
+            "type" : "aws_proxy"
+          }
+        }
+      },
+      "/todo-api/lists/{listId}/items" : {
+        "post" : {
+          "parameters" : [
+            {
+              "name" : "listId",
+              "in" : "path",
+              "required" : true,
+              "schema" : {
+                "type" : "string"
+              }
+            }
+          ],
+          "x-amazon-apigateway-integration" : {
+            "httpMethod" : "POST",
+            "uri" : "arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/${aws_lambda_function.todo-lambda.arn}/invocations",
+            "responses" : {
+              "default" : {
+                "statusCode" : "200"
+              }
+            },
+            "passthroughBehavior" : "when_no_match",
+            "contentHandling" : "CONVERT_TO_TEXT",
+            "type" : "aws_proxy"
+          }
+        },
+        "get" : {
+          "parameters" : [
+            {
+              "name" : "listId",
+              "in" : "path",
+              "required" : true,
+              "schema" : {
+                "type" : "string"
+              }
+            }
+          ],
+          "x-amazon-apigateway-integration" : {
+            "httpMethod" : "POST",
+            "uri" : "arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/${aws_lambda_function.todo-lambda.arn}/invocations",
+            "responses" : {
+              "default" : {
+                "statusCode" : "200"
+              }
+            },
+            "passthroughBehavior" : "when_no_match",
+            "contentHandling" : "CONVERT_TO_TEXT",
+            "type" : "aws_proxy"
+          }
+        }
+      },
+      "/todo-api/{id}/move" : {
+        "post" : {
+          "parameters" : [
+            {
+              "name" : "id",
+              "in" : "path",
+              "required" : true,
+              "schema" : {
+                "type" : "string"
+              }
+            }
+          ],
+          "x-amazon-apigateway-integration" : {
+            "httpMethod" : "POST",
+            "uri" : "arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/${aws_lambda_function.todo-lambda.arn}/invocations",
+            "responses" : {
+              "default" : {
+                "statusCode" : "200"
+              }
+            },
+            "passthroughBehavior" : "when_no_match",
+            "contentHandling" : "CONVERT_TO_TEXT",
+            "type" : "aws_proxy"
+          }
+        }
+      },
+      "/todo-api/{id}/checklist" : {
+        "post" : {
+          "parameters" : [
+            {
+              "name" : "id",
+              "in" : "path",
+              "required" : true,
+              "schema" : {
+                "type" : "string"
+              }
+            }
+          ],
+          "x-amazon-apigateway-integration" : {
+            "httpMethod" : "POST",
+            "uri" : "arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/${aws_lambda_function.todo-lambda.arn}/invocations",
+            "responses" : {
+              "default" : {
+                "statusCode" : "200"
+              }
+            },
+            "passthroughBehavior" : "when_no_match",
+            "contentHandling" : "CONVERT_TO_TEXT",
+            "type" : "aws_proxy"
+          }
+        }
+      },
+      "/todo-api/{id}/checklist/{entryId}/toggle" : {
+        "post" : {
+          "parameters" : [
+            {
+              "name" : "id",
+              "in" : "path",
+              "required" : true,
+              "schema" : {
+                "type" : "string"
+              }
+            },
+            {
+              "name" : "entryId",
+              "in" : "path",
+              "required" : true,
+              "schema" : {
+                "type" : "string"
+              }
+            }
+          ],
+          "x-amazon-apigateway-integration" : {
+            "httpMethod" : "POST",
+            "uri" : "arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/${aws_lambda_function.todo-lambda.arn}/invocations",
+            "responses" : {
+              "default" : {
+                "statusCode" : "200"
+              }
+            },
+            "passthroughBehavior" : "when_no_match",
+            "contentHandling" : "CONVERT_TO_TEXT",
+            "type" : "aws_proxy"
+          }
+        }
+      },
+      "/todo-api/{id}/checklist/{entryId}/move" : {
+        "post" : {
+          "parameters" : [
+            {
+              "name" : "id",
+              "in" : "path",
+              "required" : true,
+              "schema" : {
+                "type" : "string"
+              }
+            },
+            {
+              "name" : "entryId",
+              "in" : "path",
+              "required" : true,
+              "schema" : {
+                "type" : "string"
+              }
+            }
+          ],
+          "x-amazon-apigateway-integration" : {
+            "httpMethod" : "POST",
+            "uri" : "arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/${aws_lambda_function.todo-lambda.arn}/invocations",
+            "responses" : {
+              "default" : {
+                "statusCode" : "200"
+              }
+            },
+            "passthroughBehavior" : "when_no_match",
+            "contentHandling" : "CONVERT_TO_TEXT",
+            "type" : "aws_proxy"
+          }
+        }
+      },
+      "/todo-api/{id}/checklist/{entryId}" : {
+        "delete" : {
+          "parameters" : [
+            {
+              "name" : "id",
+              "in" : "path",
+              "required" : true,
+              "schema" : {
+                "type" : "string"
+              }
+            },
+            {
+              "name" : "entryId",
+              "in" : "path",
+              "required" : true,
+              "schema" : {
+                "type" : "string"
+              }
+            }
+          ],
+          "x-amazon-apigateway-integration" : {
+            "httpMethod" : "POST",
+            "uri" : "arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/${aws_lambda_function.todo-lambda.arn}/invocations",
+            "responses" : {
+              "default" : {
+                "statusCode" : "200"
+              }
+            },
+            "passthroughBehavior" : "when_no_match",
+            "contentHandling" : "CONVERT_TO_TEXT",
+            "type" : "aws_proxy"
+          }
+        }
+      },
+      "/todo-api/{id}/dependencies" : {
+        "post" : {
+          "parameters" : [
+            {
+              "name" : "id",
+              "in" : "path",
+              "required" : true,
+              "schema" : {
+                "type" : "string"
+              }
+            }
+          ],
+          "x-amazon-apigateway-integration" : {
+            "httpMethod" : "POST",
+            "uri" : "arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/${aws_lambda_function.todo-lambda.arn}/invocations",
+            "responses" : {
+              "default" : {
+                "statusCode" : "200"
+              }
+            },
+            "passthroughBehavior" : "when_no_match",
+            "contentHandling" : "CONVERT_TO_TEXT",
+            "type" : "aws_proxy"
+          }
+        }
+      },
+      "/todo-api/{id}/dependencies/{blockerId}" : {
+        "delete" : {
+          "parameters" : [
+            {
+              "name" : "id",
+              "in" : "path",
+              "required" : true,
+              "schema" : {
+                "type" : "string"
+              }
+            },
+            {
+              "name" : "blockerId",
+              "in" : "path",
+              "required" : true,
+              "schema" : {
+                "type" : "string"
+              }
+            }
+          ],
+          "x-amazon-apigateway-integration" : {
+            "httpMethod" : "POST",
+            "uri" : "arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/${aws_lambda_function.todo-lambda.arn}/invocations",
+            "responses" : {
+              "default" : {
+                "statusCode" : "200"
+              }
+            },
+            "passthroughBehavior" : "when_no_match",
+            "contentHandling" : "CONVERT_TO_TEXT",
+            "type" : "aws_proxy"
+          }
+        }
+      }
+    }
+  })
+
+  name = "todo-api"
+
+  endpoint_configuration {
+    types = ["REGIONAL"]
+  }
+}
+
+resource "aws_api_gateway_deployment" "todo-deployment" {
+  rest_api_id = aws_api_gateway_rest_api.todo-api.id
+
+  triggers = {
+    redeployment = sha1(jsonencode(aws_api_gateway_rest_api.todo-api.body))
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+resource "aws_api_gateway_stage" "todo-stage" {
+  deployment_id = aws_api_gateway_deployment.todo-deployment.id
+  rest_api_id   = aws_api_gateway_rest_api.todo-api.id
+  stage_name    = "prod"
+}
+
+resource "aws_lambda_permission" "todo-gw-lambda" {
+  action        = "lambda:InvokeFunction"
+  function_name = aws_lambda_function.todo-lambda.arn
+  principal     = "apigateway.amazonaws.com"
+
+  source_arn = "arn:aws:execute-api:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:${aws_api_gateway_rest_api.todo-api.id}/*/*/todo-api"
+}
+
+resource "aws_lambda_permission" "todo-gw-lambda-get" {
+  action        = "lambda:InvokeFunction"
+  function_name = aws_lambda_function.todo-lambda.arn
+  principal     = "apigateway.amazonaws.com"
+
+  source_arn = "arn:aws:execute-api:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:${aws_api_gateway_rest_api.todo-api.id}/*/GET/*"
+}
+
+resource "aws_lambda_permission" "todo-gw-lambda-delete" {
+  action        = "lambda:InvokeFunction"
+  function_name = aws_lambda_function.todo-lambda.arn
+  principal     = "apigateway.amazonaws.com"
+
+  source_arn = "arn:aws:execute-api:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:${aws_api_gateway_rest_api.todo-api.id}/*/DELETE/*"
+}
+
+resource "aws_lambda_permission" "todo-gw-lambda-put" {
+  action        = "lambda:InvokeFunction"
+  function_name = aws_lambda_function.todo-lambda.arn
+  principal     = "apigateway.amazonaws.com"
+
+  source_arn = "arn:aws:execute-api:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:${aws_api_gateway_rest_api.todo-api.id}/*/PUT/*"
+}
+
+resource "aws_lambda_permission" "todo-gw-lambda-patch" {
+  action        = "lambda:InvokeFunction"
+  function_name = aws_lambda_function.todo-lambda.arn
+  principal     = "apigateway.amazonaws.com"
+
+  source_arn = "arn:aws:execute-api:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:${aws_api_gateway_rest_api.todo-api.id}/*/PATCH/*"
+}
+
+resource "aws_lambda_permission" "todo-gw-lambda-post" {
+  action        = "lambda:InvokeFunction"
+  function_name = aws_lambda_function.todo-lambda.arn
+  principal     = "apigateway.amazonaws.com"
+
+  source_arn = "arn:aws:execute-api:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:${aws_api_gateway_rest_api.todo-api.id}/*/POST/*"
+}
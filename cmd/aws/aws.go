@@ -1,13 +1,17 @@
 package aws
 
 import (
+	"log"
+
 	"github.com/BrunoDM2943/go-todo-lambda/internal/cdi"
-	"github.com/BrunoDM2943/go-todo-lambda/internal/handler/function"
 	"github.com/aws/aws-lambda-go/lambda"
 )
 
 func StartLambda() {
-	handler := function.NewLambdaHandler(cdi.GetTodoService())
-	handler.BuildRoutes()
-	lambda.Start(handler.HandleRequest)
+	app, err := cdi.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer app.Close()
+	lambda.Start(app.Handler.HandleRequest)
 }
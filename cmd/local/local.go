@@ -0,0 +1,39 @@
+package local
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/cdi"
+	"github.com/BrunoDM2943/go-todo-lambda/internal/handler/function"
+)
+
+func StartServer(address string) {
+	app, err := cdi.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer app.Close()
+
+	server := &http.Server{Addr: address, Handler: function.NewHTTPHandler(app.Handler)}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		<-ctx.Done()
+		server.Shutdown(context.Background())
+	}()
+
+	app.Logger.Infof("Serving the todo API on %s", address)
+	if err := server.ListenAndServe(); err != http.ErrServerClosed {
+		app.Logger.Errorf("Server stopped: %v", err)
+		return
+	}
+	// Let in-flight requests finish before the repository is closed.
+	<-drained
+}
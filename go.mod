@@ -1,11 +1,13 @@
 module github.com/BrunoDM2943/go-todo-lambda
 
-go 1.13
+go 1.16
 
 require (
 	github.com/aws/aws-lambda-go v1.23.0
 	github.com/aws/aws-sdk-go v1.38.0
 	github.com/golang/mock v1.5.0
 	github.com/google/uuid v1.2.0
+	github.com/lib/pq v1.10.2
 	github.com/stretchr/testify v1.6.1
+	go.etcd.io/bbolt v1.3.5
 )
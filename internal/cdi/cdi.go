@@ -1,15 +1,107 @@
 package cdi
 
 import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/config"
+	"github.com/BrunoDM2943/go-todo-lambda/internal/handler/function"
+	"github.com/BrunoDM2943/go-todo-lambda/internal/logging"
+	"github.com/BrunoDM2943/go-todo-lambda/internal/metrics"
+	"github.com/BrunoDM2943/go-todo-lambda/internal/module/lists"
 	"github.com/BrunoDM2943/go-todo-lambda/internal/module/todo"
 	"github.com/BrunoDM2943/go-todo-lambda/internal/repository"
 )
 
-var todoService todo.Service
+// App owns every long-lived dependency of the service. Entrypoints build one
+// and Close it when they stop.
+type App struct {
+	Config     *config.Config
+	Logger     *logging.Logger
+	Metrics    metrics.Recorder
+	Repository repository.TodoRepository
+	Service    todo.Service
+	// Lists is nil when the repository does not store lists.
+	Lists   lists.Service
+	Handler *function.LambdaHandler
+}
+
+// Load builds the App from the process environment.
+func Load() (*App, error) {
+	settings, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	return NewApp(settings)
+}
+
+func NewApp(settings *config.Config) (*App, error) {
+	repo, err := newRepository(settings)
+	if err != nil {
+		return nil, err
+	}
+	return NewAppWithRepository(settings, repo), nil
+}
+
+// NewAppWithRepository wires the App around an existing repository, e.g. a
+// decorated one or a test double.
+func NewAppWithRepository(settings *config.Config, repo repository.TodoRepository) *App {
+	app := &App{
+		Config:     settings,
+		Logger:     logging.New(os.Stderr, settings.LogLevel),
+		Metrics:    metrics.Nop(),
+		Repository: repo,
+	}
+	if settings.MetricsNamespace != "" {
+		app.Metrics = metrics.NewEMF(os.Stdout, settings.MetricsNamespace)
+	}
+	app.Service = todo.NewTodoService(repo)
+	options := []function.Option{
+		function.WithLogger(app.Logger),
+		function.WithMetrics(app.Metrics),
+	}
+	if listRepo, ok := repo.(repository.ListRepository); ok {
+		app.Lists = lists.NewListService(listRepo, repo, app.Service)
+		options = append(options, function.WithListService(app.Lists))
+	}
+	app.Handler = function.NewLambdaHandler(app.Service, options...)
+	app.Handler.BuildRoutes()
+	return app
+}
+
+// Close releases the repository, when it holds a file or connection.
+func (app *App) Close() error {
+	if closer, ok := app.Repository.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
 
-func GetTodoService() todo.Service {
-	if todoService == nil {
-		todoService = todo.NewTodoService(repository.NewDynamoDB())
+func newRepository(settings *config.Config) (repository.TodoRepository, error) {
+	switch settings.Backend {
+	case config.BackendMemory:
+		return repository.NewInMemory(), nil
+	case config.BackendBolt:
+		repo, err := repository.NewBolt(settings.Bolt.Path)
+		if err != nil {
+			return nil, fmt.Errorf("could not open %s: %w", settings.Bolt.Path, err)
+		}
+		return repo, nil
+	case config.BackendPostgres:
+		repo, err := repository.NewPostgres(settings.Postgres.DSN)
+		if err != nil {
+			return nil, fmt.Errorf("could not connect to PostgreSQL: %w", err)
+		}
+		if settings.Postgres.Migrate {
+			if err := repo.MigrateUp(context.Background()); err != nil {
+				repo.Close()
+				return nil, fmt.Errorf("could not migrate PostgreSQL: %w", err)
+			}
+		}
+		return repo, nil
+	default:
+		return repository.NewDynamoDB(settings.DynamoDB), nil
 	}
-	return todoService
 }
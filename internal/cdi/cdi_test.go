@@ -0,0 +1,83 @@
+package cdi
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/config"
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+	"github.com/BrunoDM2943/go-todo-lambda/internal/logging"
+)
+
+func TestNewApp(t *testing.T) {
+	t.Run("Wires the handler to the configured backend", func(t *testing.T) {
+		app, err := NewApp(&config.Config{Backend: config.BackendMemory, LogLevel: logging.LevelError})
+		assert.Nil(t, err)
+		defer app.Close()
+
+		response, err := app.Handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "POST",
+			Resource:   "/todo-api",
+			Body:       `{"title":"List","text":"Homework"}`,
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+		page, err := app.Repository.List(context.TODO(), model.ListQuery{Limit: 10})
+		assert.Nil(t, err)
+		assert.Len(t, page.Items, 1)
+	})
+
+	t.Run("Serves lists on backends that store them", func(t *testing.T) {
+		app, err := NewApp(&config.Config{Backend: config.BackendMemory, LogLevel: logging.LevelError})
+		assert.Nil(t, err)
+		defer app.Close()
+
+		list, err := app.Lists.PostList(context.TODO(), &model.List{Name: "Work"})
+		assert.Nil(t, err)
+		response, err := app.Handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:     "POST",
+			Resource:       "/todo-api/lists/{listId}/items",
+			PathParameters: map[string]string{"listId": list.ID},
+			Body:           `{"title":"Report","text":"Quarterly"}`,
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+		page, err := app.Lists.GetListItems(context.TODO(), list.ID, model.ListQuery{})
+		assert.Nil(t, err)
+		assert.Len(t, page.Items, 1)
+	})
+
+	t.Run("Apps do not share state", func(t *testing.T) {
+		first, _ := NewApp(&config.Config{Backend: config.BackendMemory})
+		second, _ := NewApp(&config.Config{Backend: config.BackendMemory})
+		_, err := first.Service.PostItem(context.TODO(), &model.Item{Title: "List", Text: "Homework"})
+		assert.Nil(t, err)
+
+		page, err := second.Service.GetItems(context.TODO(), model.ListQuery{})
+		assert.Nil(t, err)
+		assert.Empty(t, page.Items)
+	})
+
+	t.Run("Close releases the bolt file", func(t *testing.T) {
+		settings := &config.Config{Backend: config.BackendBolt, Bolt: config.Bolt{Path: filepath.Join(t.TempDir(), "todo.db")}}
+		app, err := NewApp(settings)
+		assert.Nil(t, err)
+		assert.Nil(t, app.Close())
+
+		reopened, err := NewApp(settings)
+		assert.Nil(t, err)
+		assert.Nil(t, reopened.Close())
+	})
+
+	t.Run("Reports backend failures", func(t *testing.T) {
+		_, err := NewApp(&config.Config{Backend: config.BackendBolt, Bolt: config.Bolt{Path: t.TempDir()}})
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,131 @@
+// Package config reads the service configuration from the environment once,
+// at cold start, so a bad deployment fails before serving any request.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/logging"
+)
+
+const (
+	BackendDynamoDB = "dynamodb"
+	BackendMemory   = "memory"
+	BackendBolt     = "bolt"
+	BackendPostgres = "postgres"
+)
+
+var (
+	backends          = []string{BackendDynamoDB, BackendMemory, BackendBolt, BackendPostgres}
+	dynamoTableFormat = regexp.MustCompile(`^[a-zA-Z0-9_.-]{3,255}$`)
+)
+
+type Config struct {
+	Backend  string
+	LogLevel logging.Level
+	// MetricsNamespace enables CloudWatch metrics when set.
+	MetricsNamespace string
+	DynamoDB         DynamoDB
+	Bolt             Bolt
+	Postgres         Postgres
+}
+
+type DynamoDB struct {
+	Table string
+	// ListsTable holds the todo lists, next to the items in Table.
+	ListsTable string
+	Endpoint   string
+	// Region overrides the one resolved from the shared AWS configuration.
+	Region string
+}
+
+type Bolt struct {
+	Path string
+}
+
+type Postgres struct {
+	DSN     string
+	Migrate bool
+}
+
+// Load reads the configuration from the process environment.
+func Load() (*Config, error) {
+	return load(os.Getenv)
+}
+
+func load(getenv func(string) string) (*Config, error) {
+	var problems []string
+	config := &Config{
+		Backend:          withDefault(getenv("TODO_BACKEND"), BackendDynamoDB),
+		MetricsNamespace: getenv("TODO_METRICS_NAMESPACE"),
+		DynamoDB: DynamoDB{
+			Table:    withDefault(getenv("TODO_DYNAMODB_TABLE"), "todo"),
+			Endpoint: getenv("TODO_DYNAMODB_ENDPOINT"),
+			Region:   getenv("TODO_DYNAMODB_REGION"),
+		},
+		Bolt: Bolt{
+			Path: withDefault(getenv("TODO_BOLT_PATH"), "todo.db"),
+		},
+		Postgres: Postgres{
+			DSN: getenv("TODO_POSTGRES_DSN"),
+		},
+	}
+
+	if !oneOf(config.Backend, backends) {
+		problems = append(problems, fmt.Sprintf("TODO_BACKEND %q must be one of %s", config.Backend, strings.Join(backends, ", ")))
+	}
+	level, err := logging.ParseLevel(withDefault(getenv("TODO_LOG_LEVEL"), "info"))
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("TODO_LOG_LEVEL: %v", err))
+	}
+	config.LogLevel = level
+	if !dynamoTableFormat.MatchString(config.DynamoDB.Table) {
+		problems = append(problems, fmt.Sprintf("TODO_DYNAMODB_TABLE %q is not a valid table name", config.DynamoDB.Table))
+	}
+	config.DynamoDB.ListsTable = withDefault(getenv("TODO_DYNAMODB_LISTS_TABLE"), config.DynamoDB.Table+"-lists")
+	if !dynamoTableFormat.MatchString(config.DynamoDB.ListsTable) {
+		problems = append(problems, fmt.Sprintf("TODO_DYNAMODB_LISTS_TABLE %q is not a valid table name", config.DynamoDB.ListsTable))
+	}
+	if endpoint := config.DynamoDB.Endpoint; endpoint != "" {
+		if parsed, err := url.Parse(endpoint); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			problems = append(problems, fmt.Sprintf("TODO_DYNAMODB_ENDPOINT %q must be an absolute URL", endpoint))
+		}
+	}
+	if migrate := getenv("TODO_POSTGRES_MIGRATE"); migrate != "" {
+		parsed, err := strconv.ParseBool(migrate)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("TODO_POSTGRES_MIGRATE %q must be true or false", migrate))
+		}
+		config.Postgres.Migrate = parsed
+	}
+	if config.Backend == BackendPostgres && config.Postgres.DSN == "" {
+		problems = append(problems, "TODO_POSTGRES_DSN is required by the postgres backend")
+	}
+
+	if len(problems) > 0 {
+		return nil, errors.New("invalid configuration: " + strings.Join(problems, "; "))
+	}
+	return config, nil
+}
+
+func withDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+func oneOf(value string, allowed []string) bool {
+	for _, candidate := range allowed {
+		if value == candidate {
+			return true
+		}
+	}
+	return false
+}
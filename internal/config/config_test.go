@@ -0,0 +1,72 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/logging"
+)
+
+func env(values map[string]string) func(string) string {
+	return func(key string) string {
+		return values[key]
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		config, err := load(env(nil))
+		assert.Nil(t, err)
+		assert.Equal(t, &Config{
+			Backend:  BackendDynamoDB,
+			LogLevel: logging.LevelInfo,
+			DynamoDB: DynamoDB{Table: "todo", ListsTable: "todo-lists"},
+			Bolt:     Bolt{Path: "todo.db"},
+		}, config)
+	})
+
+	t.Run("Overrides", func(t *testing.T) {
+		config, err := load(env(map[string]string{
+			"TODO_BACKEND":              "postgres",
+			"TODO_LOG_LEVEL":            "DEBUG",
+			"TODO_METRICS_NAMESPACE":    "TodoAPI",
+			"TODO_DYNAMODB_TABLE":       "todo-staging",
+			"TODO_DYNAMODB_LISTS_TABLE": "lists-staging",
+			"TODO_DYNAMODB_ENDPOINT":    "http://localhost:8000",
+			"TODO_DYNAMODB_REGION":      "sa-east-1",
+			"TODO_POSTGRES_DSN":         "postgres://localhost/todo",
+			"TODO_POSTGRES_MIGRATE":     "true",
+		}))
+		assert.Nil(t, err)
+		assert.Equal(t, BackendPostgres, config.Backend)
+		assert.Equal(t, logging.LevelDebug, config.LogLevel)
+		assert.Equal(t, "TodoAPI", config.MetricsNamespace)
+		assert.Equal(t, DynamoDB{Table: "todo-staging", ListsTable: "lists-staging", Endpoint: "http://localhost:8000", Region: "sa-east-1"}, config.DynamoDB)
+		assert.Equal(t, Postgres{DSN: "postgres://localhost/todo", Migrate: true}, config.Postgres)
+	})
+
+	t.Run("Reports every problem at once", func(t *testing.T) {
+		_, err := load(env(map[string]string{
+			"TODO_BACKEND":              "sqlite",
+			"TODO_LOG_LEVEL":            "verbose",
+			"TODO_DYNAMODB_TABLE":       "a",
+			"TODO_DYNAMODB_LISTS_TABLE": "b",
+			"TODO_DYNAMODB_ENDPOINT":    "localhost",
+			"TODO_POSTGRES_MIGRATE":     "yes please",
+		}))
+		if assert.NotNil(t, err) {
+			assert.Contains(t, err.Error(), "TODO_BACKEND")
+			assert.Contains(t, err.Error(), "TODO_LOG_LEVEL")
+			assert.Contains(t, err.Error(), "TODO_DYNAMODB_TABLE")
+			assert.Contains(t, err.Error(), "TODO_DYNAMODB_LISTS_TABLE")
+			assert.Contains(t, err.Error(), "TODO_DYNAMODB_ENDPOINT")
+			assert.Contains(t, err.Error(), "TODO_POSTGRES_MIGRATE")
+		}
+	})
+
+	t.Run("Postgres needs a DSN", func(t *testing.T) {
+		_, err := load(env(map[string]string{"TODO_BACKEND": "postgres"}))
+		assert.EqualError(t, err, "invalid configuration: TODO_POSTGRES_DSN is required by the postgres backend")
+	})
+}
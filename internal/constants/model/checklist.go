@@ -0,0 +1,34 @@
+package model
+
+import "fmt"
+
+// ChecklistEntry is one step of an item. Entries are kept in Position
+// order, which runs from 0 without gaps.
+type ChecklistEntry struct {
+	ID       string `json:"id"`
+	Text     string `json:"text"`
+	Done     bool   `json:"done"`
+	Position int    `json:"position"`
+}
+
+// Progress rolls a checklist up. It is derived, never stored.
+type Progress struct {
+	Done    int    `json:"done"`
+	Total   int    `json:"total"`
+	Summary string `json:"summary"`
+}
+
+// ChecklistProgress is nil for items without a checklist.
+func ChecklistProgress(entries []ChecklistEntry) *Progress {
+	if len(entries) == 0 {
+		return nil
+	}
+	progress := &Progress{Total: len(entries)}
+	for _, entry := range entries {
+		if entry.Done {
+			progress.Done++
+		}
+	}
+	progress.Summary = fmt.Sprintf("%d/%d done", progress.Done, progress.Total)
+	return progress
+}
@@ -1,7 +1,52 @@
 package model
 
+import "time"
+
+// Priorities run from P0, the most urgent, to P3. Items without one are
+// DefaultPriority.
+const (
+	PriorityP0      = "P0"
+	PriorityP1      = "P1"
+	PriorityP2      = "P2"
+	PriorityP3      = "P3"
+	DefaultPriority = PriorityP2
+)
+
+var Priorities = map[string]bool{
+	PriorityP0: true,
+	PriorityP1: true,
+	PriorityP2: true,
+	PriorityP3: true,
+}
+
 type Item struct {
-	ID    string `json:"ID"`
-	Title string `json:"title"`
-	Text  string `json:"text"`
+	ID          string     `json:"ID"`
+	Title       string     `json:"title"`
+	Text        string     `json:"text"`
+	Done        bool       `json:"done"`
+	Priority    string     `json:"priority,omitempty"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	// DueAt is stored in UTC with second precision.
+	DueAt *time.Time `json:"dueAt,omitempty"`
+	// Tags is kept sorted and free of duplicates.
+	Tags      []string         `json:"tags,omitempty" dynamodbav:"tags,stringset,omitempty"`
+	Checklist []ChecklistEntry `json:"checklist,omitempty"`
+	// BlockedBy holds the IDs of the open items this one waits on, sorted.
+	BlockedBy []string   `json:"blockedBy,omitempty" dynamodbav:"blockedBy,stringset,omitempty"`
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+	Version   int64      `json:"version"`
+	// ListID is empty for items that belong to no list.
+	ListID string `json:"listId,omitempty"`
+	// Archived items are hidden from the default views.
+	Archived bool `json:"archived,omitempty"`
+}
+
+// List groups items, like a project or a context.
+type List struct {
+	ID        string     `json:"ID"`
+	Name      string     `json:"name"`
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+	Version   int64      `json:"version"`
 }
@@ -0,0 +1,11 @@
+package model
+
+import "errors"
+
+var (
+	ErrNotFound   = errors.New("item not found")
+	ErrValidation = errors.New("validation failed")
+	ErrConflict   = errors.New("conflict")
+	// ErrVersionMismatch means the item changed since the caller read it.
+	ErrVersionMismatch = errors.New("version mismatch")
+)
@@ -0,0 +1,65 @@
+package model
+
+import "time"
+
+const (
+	StatusOpen = "open"
+	StatusDone = "done"
+)
+
+const (
+	SortByTitle     = "title"
+	SortByCreatedAt = "created_at"
+	SortByUpdatedAt = "updated_at"
+	SortByDueDate   = "due_date"
+)
+
+var SortableFields = map[string]bool{
+	SortByTitle:     true,
+	SortByCreatedAt: true,
+	SortByUpdatedAt: true,
+	SortByDueDate:   true,
+}
+
+const (
+	TagMatchAll = "all"
+	TagMatchAny = "any"
+)
+
+// ItemFilter narrows a listing. Due bounds only match items with a due date:
+// DueAfter is inclusive and DueBefore exclusive.
+type ItemFilter struct {
+	Status    string
+	Query     string
+	DueAfter  *time.Time
+	DueBefore *time.Time
+	// Tags matches items carrying all of the tags, or any of them when
+	// TagMatch is TagMatchAny.
+	Tags     []string
+	TagMatch string
+	ListID   string
+	// Archived restricts the listing to archived items when true and to the
+	// others when false. Nil matches both.
+	Archived *bool
+	// Blocked works like Archived for items waiting on others.
+	Blocked *bool
+	// BlockedBy matches the items waiting on the item with that ID.
+	BlockedBy string
+}
+
+type SortOrder struct {
+	Field      string
+	Descending bool
+}
+
+type ListQuery struct {
+	Filter ItemFilter
+	Sort   SortOrder
+	Limit  int
+	Cursor string
+}
+
+type Page struct {
+	Items      []*Item `json:"items"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,12 @@
+package model
+
+// ContainsString tells whether wanted is one of values, such as a tag of an
+// item or one of the items it waits on.
+func ContainsString(values []string, wanted string) bool {
+	for _, value := range values {
+		if value == wanted {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,6 @@
+package model
+
+type TagCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
@@ -0,0 +1,70 @@
+package function
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// The checklist routes take no If-Match, see transitionItem.
+func (handler *LambdaHandler) addChecklistEntryHandler(ctx context.Context, request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	id := request.PathParameters["id"]
+	if id == "" {
+		return buildProblemResponse(request, http.StatusBadRequest, "Invalid ID")
+	}
+	body := struct {
+		Text string `json:"text"`
+	}{}
+	if err := json.Unmarshal([]byte(request.Body), &body); err != nil || body.Text == "" {
+		return buildProblemResponse(request, http.StatusBadRequest, "Invalid body, expected the entry text")
+	}
+	item, err := handler.todoService.AddChecklistEntry(ctx, id, body.Text)
+	if err != nil {
+		return handler.buildErrorResponse(request, err)
+	}
+	return buildItemResponse(item)
+}
+
+func (handler *LambdaHandler) toggleChecklistEntryHandler(ctx context.Context, request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	id, entryID := request.PathParameters["id"], request.PathParameters["entryId"]
+	if id == "" || entryID == "" {
+		return buildProblemResponse(request, http.StatusBadRequest, "Invalid ID")
+	}
+	item, err := handler.todoService.ToggleChecklistEntry(ctx, id, entryID)
+	if err != nil {
+		return handler.buildErrorResponse(request, err)
+	}
+	return buildItemResponse(item)
+}
+
+func (handler *LambdaHandler) moveChecklistEntryHandler(ctx context.Context, request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	id, entryID := request.PathParameters["id"], request.PathParameters["entryId"]
+	if id == "" || entryID == "" {
+		return buildProblemResponse(request, http.StatusBadRequest, "Invalid ID")
+	}
+	body := struct {
+		Position *int `json:"position"`
+	}{}
+	if err := json.Unmarshal([]byte(request.Body), &body); err != nil || body.Position == nil {
+		return buildProblemResponse(request, http.StatusBadRequest, "Invalid body, expected the new position")
+	}
+	item, err := handler.todoService.MoveChecklistEntry(ctx, id, entryID, *body.Position)
+	if err != nil {
+		return handler.buildErrorResponse(request, err)
+	}
+	return buildItemResponse(item)
+}
+
+func (handler *LambdaHandler) removeChecklistEntryHandler(ctx context.Context, request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	id, entryID := request.PathParameters["id"], request.PathParameters["entryId"]
+	if id == "" || entryID == "" {
+		return buildProblemResponse(request, http.StatusBadRequest, "Invalid ID")
+	}
+	item, err := handler.todoService.RemoveChecklistEntry(ctx, id, entryID)
+	if err != nil {
+		return handler.buildErrorResponse(request, err)
+	}
+	return buildItemResponse(item)
+}
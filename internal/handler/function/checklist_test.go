@@ -0,0 +1,124 @@
+package function
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+	"github.com/BrunoDM2943/go-todo-lambda/internal/module/todo"
+	mock_todo "github.com/BrunoDM2943/go-todo-lambda/internal/module/todo/mock"
+)
+
+func TestChecklistHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mock_todo.NewMockService(ctrl)
+	handler := NewLambdaHandler(mockService)
+	handler.BuildRoutes()
+	withChecklist := &model.Item{ID: defaultID, Version: 4, Checklist: []model.ChecklistEntry{
+		{ID: "a", Text: "outline", Done: true, Position: 0},
+		{ID: "b", Text: "draft", Position: 1},
+	}}
+
+	t.Run("Test Get Item - Progress", func(t *testing.T) {
+		mockService.EXPECT().GetItem(gomock.Any(), defaultID).Return(withChecklist, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:     "GET",
+			Resource:       "/todo-api/{id}",
+			PathParameters: map[string]string{"id": defaultID},
+		})
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Contains(t, response.Body, `"progress":{"done":1,"total":2,"summary":"1/2 done"}`)
+	})
+
+	t.Run("Test Get Items - Progress", func(t *testing.T) {
+		mockService.EXPECT().GetItems(gomock.Any(), gomock.Any()).Return(&model.Page{Items: []*model.Item{withChecklist, {ID: "plain"}}}, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "GET",
+			Resource:   "/todo-api",
+		})
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.JSONEq(t, `{"items":[
+			{"ID":"xpto","title":"","text":"","done":false,"version":4,
+			 "checklist":[{"id":"a","text":"outline","done":true,"position":0},{"id":"b","text":"draft","done":false,"position":1}],
+			 "progress":{"done":1,"total":2,"summary":"1/2 done"}},
+			{"ID":"plain","title":"","text":"","done":false,"version":0}
+		]}`, response.Body)
+	})
+
+	t.Run("Test Add Entry - OK", func(t *testing.T) {
+		mockService.EXPECT().AddChecklistEntry(gomock.Any(), defaultID, "review").Return(withChecklist, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:     "POST",
+			Resource:       "/todo-api/{id}/checklist",
+			PathParameters: map[string]string{"id": defaultID},
+			Body:           `{"text":"review"}`,
+		})
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, `"4"`, response.Headers["ETag"])
+	})
+
+	t.Run("Test Add Entry - Missing text", func(t *testing.T) {
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:     "POST",
+			Resource:       "/todo-api/{id}/checklist",
+			PathParameters: map[string]string{"id": defaultID},
+			Body:           `{}`,
+		})
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("Test Toggle Entry - Not found", func(t *testing.T) {
+		mockService.EXPECT().ToggleChecklistEntry(gomock.Any(), defaultID, "z").Return(nil, todo.ErrNotFound)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:     "POST",
+			Resource:       "/todo-api/{id}/checklist/{entryId}/toggle",
+			PathParameters: map[string]string{"id": defaultID, "entryId": "z"},
+		})
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	})
+
+	t.Run("Test Move Entry - OK", func(t *testing.T) {
+		mockService.EXPECT().MoveChecklistEntry(gomock.Any(), defaultID, "b", 0).Return(withChecklist, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:     "POST",
+			Resource:       "/todo-api/{id}/checklist/{entryId}/move",
+			PathParameters: map[string]string{"id": defaultID, "entryId": "b"},
+			Body:           `{"position":0}`,
+		})
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("Test Move Entry - Missing position", func(t *testing.T) {
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:     "POST",
+			Resource:       "/todo-api/{id}/checklist/{entryId}/move",
+			PathParameters: map[string]string{"id": defaultID, "entryId": "b"},
+			Body:           `{}`,
+		})
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("Test Remove Entry - OK", func(t *testing.T) {
+		mockService.EXPECT().RemoveChecklistEntry(gomock.Any(), defaultID, "a").Return(&model.Item{ID: defaultID}, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:     "DELETE",
+			Resource:       "/todo-api/{id}/checklist/{entryId}",
+			PathParameters: map[string]string{"id": defaultID, "entryId": "a"},
+		})
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.NotContains(t, response.Body, "progress")
+	})
+}
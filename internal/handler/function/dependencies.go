@@ -0,0 +1,40 @@
+package function
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// The dependency routes take no If-Match, see transitionItem.
+func (handler *LambdaHandler) addDependencyHandler(ctx context.Context, request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	id := request.PathParameters["id"]
+	if id == "" {
+		return buildProblemResponse(request, http.StatusBadRequest, "Invalid ID")
+	}
+	body := struct {
+		BlockedBy string `json:"blockedBy"`
+	}{}
+	if err := json.Unmarshal([]byte(request.Body), &body); err != nil || body.BlockedBy == "" {
+		return buildProblemResponse(request, http.StatusBadRequest, "Invalid body, expected the ID of the blocking item")
+	}
+	item, err := handler.todoService.AddDependency(ctx, id, body.BlockedBy)
+	if err != nil {
+		return handler.buildErrorResponse(request, err)
+	}
+	return buildItemResponse(item)
+}
+
+func (handler *LambdaHandler) removeDependencyHandler(ctx context.Context, request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	id, blockerID := request.PathParameters["id"], request.PathParameters["blockerId"]
+	if id == "" || blockerID == "" {
+		return buildProblemResponse(request, http.StatusBadRequest, "Invalid ID")
+	}
+	item, err := handler.todoService.RemoveDependency(ctx, id, blockerID)
+	if err != nil {
+		return handler.buildErrorResponse(request, err)
+	}
+	return buildItemResponse(item)
+}
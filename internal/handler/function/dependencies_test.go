@@ -0,0 +1,94 @@
+package function
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+	"github.com/BrunoDM2943/go-todo-lambda/internal/module/todo"
+	mock_todo "github.com/BrunoDM2943/go-todo-lambda/internal/module/todo/mock"
+)
+
+func TestDependencyHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mock_todo.NewMockService(ctrl)
+	handler := NewLambdaHandler(mockService)
+	handler.BuildRoutes()
+
+	t.Run("Test Add Dependency - OK", func(t *testing.T) {
+		mockService.EXPECT().AddDependency(gomock.Any(), defaultID, "draft").Return(&model.Item{ID: defaultID, BlockedBy: []string{"draft"}, Version: 2}, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:     "POST",
+			Resource:       "/todo-api/{id}/dependencies",
+			PathParameters: map[string]string{"id": defaultID},
+			Body:           `{"blockedBy":"draft"}`,
+		})
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Contains(t, response.Body, `"blockedBy":["draft"]`)
+		assert.Equal(t, `"2"`, response.Headers["ETag"])
+	})
+
+	t.Run("Test Add Dependency - Missing blocker", func(t *testing.T) {
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:     "POST",
+			Resource:       "/todo-api/{id}/dependencies",
+			PathParameters: map[string]string{"id": defaultID},
+			Body:           `{}`,
+		})
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("Test Add Dependency - Cycle", func(t *testing.T) {
+		mockService.EXPECT().AddDependency(gomock.Any(), defaultID, "draft").Return(nil, todo.ErrValidation)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:     "POST",
+			Resource:       "/todo-api/{id}/dependencies",
+			PathParameters: map[string]string{"id": defaultID},
+			Body:           `{"blockedBy":"draft"}`,
+		})
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("Test Remove Dependency - Not found", func(t *testing.T) {
+		mockService.EXPECT().RemoveDependency(gomock.Any(), defaultID, "draft").Return(nil, todo.ErrNotFound)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:     "DELETE",
+			Resource:       "/todo-api/{id}/dependencies/{blockerId}",
+			PathParameters: map[string]string{"id": defaultID, "blockerId": "draft"},
+		})
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	})
+
+	t.Run("Test Get Items - Blocked filter", func(t *testing.T) {
+		blocked := true
+		mockService.EXPECT().GetItems(gomock.Any(), model.ListQuery{
+			Filter: model.ItemFilter{Archived: &unarchived, Blocked: &blocked},
+		}).Return(&model.Page{}, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:            "GET",
+			Resource:              "/todo-api",
+			QueryStringParameters: map[string]string{"blocked": "true"},
+		})
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("Test Get Items - Invalid blocked filter", func(t *testing.T) {
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:            "GET",
+			Resource:              "/todo-api",
+			QueryStringParameters: map[string]string{"blocked": "maybe"},
+		})
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+}
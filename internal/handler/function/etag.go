@@ -0,0 +1,70 @@
+package function
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/module/todo"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// formatETag exposes an item version as a strong entity tag.
+func formatETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// contentETag tags a representation that has no version of its own, such
+// as a page of items, by hashing it.
+func contentETag(body string) string {
+	sum := sha256.Sum256([]byte(body))
+	return strconv.Quote(hex.EncodeToString(sum[:16]))
+}
+
+// notModified evaluates the conditional GET headers. If-None-Match uses the
+// weak comparison and, when present, takes precedence over
+// If-Modified-Since, which is ignored for resources without lastModified.
+func notModified(request events.APIGatewayProxyRequest, etag string, lastModified *time.Time) bool {
+	if header := headerValue(request, "If-None-Match"); header != "" {
+		for _, candidate := range strings.Split(header, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+	if lastModified == nil {
+		return false
+	}
+	since, err := http.ParseTime(headerValue(request, "If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	// HTTP dates have no fractional seconds.
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// expectedVersion reads the If-Match header that writes must carry. When it
+// is missing or cannot match any version it returns the response to send.
+func expectedVersion(request events.APIGatewayProxyRequest) (int64, *events.APIGatewayProxyResponse) {
+	value := strings.TrimSpace(headerValue(request, "If-Match"))
+	if value == "" {
+		response := buildProblemResponse(request, http.StatusPreconditionRequired, "Send the item ETag in If-Match")
+		return 0, &response
+	}
+	if value == "*" {
+		return todo.AnyVersion, nil
+	}
+	// Weak tags never match under the strong comparison If-Match requires.
+	if len(value) > 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		if version, err := strconv.ParseInt(value[1:len(value)-1], 10, 64); err == nil && version >= 0 {
+			return version, nil
+		}
+	}
+	response := buildProblemResponse(request, http.StatusPreconditionFailed, "If-Match does not match the current version")
+	return 0, &response
+}
@@ -0,0 +1,138 @@
+package function
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/google/uuid"
+)
+
+type httpAdapter struct {
+	handler   *LambdaHandler
+	resources []string
+}
+
+// NewHTTPHandler serves the lambda routes over plain HTTP by translating each
+// request into the event API Gateway would have sent.
+func NewHTTPHandler(handler *LambdaHandler) http.Handler {
+	unique := map[string]bool{}
+	for key := range handler.routes {
+		unique[key[strings.Index(key, ":")+1:]] = true
+	}
+	resources := make([]string, 0, len(unique))
+	for resource := range unique {
+		resources = append(resources, resource)
+	}
+	// Literal segments win over parameters, as they do in API Gateway.
+	sort.Slice(resources, func(i, j int) bool {
+		if literalSegments(resources[i]) != literalSegments(resources[j]) {
+			return literalSegments(resources[i]) > literalSegments(resources[j])
+		}
+		return resources[i] < resources[j]
+	})
+	return &httpAdapter{
+		handler:   handler,
+		resources: resources,
+	}
+}
+
+func (adapter *httpAdapter) ServeHTTP(writer http.ResponseWriter, httpRequest *http.Request) {
+	body, err := ioutil.ReadAll(httpRequest.Body)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	request := events.APIGatewayProxyRequest{
+		HTTPMethod:                      httpRequest.Method,
+		Path:                            httpRequest.URL.Path,
+		Headers:                         firstValues(httpRequest.Header),
+		MultiValueHeaders:               httpRequest.Header,
+		QueryStringParameters:           firstValues(httpRequest.URL.Query()),
+		MultiValueQueryStringParameters: httpRequest.URL.Query(),
+		Body:                            string(body),
+		RequestContext: events.APIGatewayProxyRequestContext{
+			RequestID:  uuid.NewString(),
+			Stage:      "local",
+			HTTPMethod: httpRequest.Method,
+		},
+	}
+	for _, resource := range adapter.resources {
+		if parameters, ok := matchResource(resource, httpRequest.URL.EscapedPath()); ok {
+			request.Resource = resource
+			request.RequestContext.ResourcePath = resource
+			request.PathParameters = parameters
+			break
+		}
+	}
+	if request.Resource == "" {
+		http.NotFound(writer, httpRequest)
+		return
+	}
+
+	response, err := adapter.handler.HandleRequest(httpRequest.Context(), request)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	for key, value := range response.Headers {
+		writer.Header().Set(key, value)
+	}
+	for key, values := range response.MultiValueHeaders {
+		writer.Header()[http.CanonicalHeaderKey(key)] = values
+	}
+	writer.WriteHeader(response.StatusCode)
+	responseBody := []byte(response.Body)
+	if response.IsBase64Encoded {
+		if responseBody, err = base64.StdEncoding.DecodeString(response.Body); err != nil {
+			adapter.handler.logger.Errorf("Could not decode response body: %v", err)
+			return
+		}
+	}
+	_, _ = writer.Write(responseBody)
+}
+
+func matchResource(resource string, path string) (map[string]string, bool) {
+	templateSegments := strings.Split(strings.Trim(resource, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(templateSegments) != len(pathSegments) {
+		return nil, false
+	}
+	parameters := map[string]string{}
+	for i, segment := range templateSegments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			value, err := url.PathUnescape(pathSegments[i])
+			if err != nil || value == "" {
+				return nil, false
+			}
+			parameters[segment[1:len(segment)-1]] = value
+		} else if segment != pathSegments[i] {
+			return nil, false
+		}
+	}
+	return parameters, true
+}
+
+func literalSegments(resource string) int {
+	count := 0
+	for _, segment := range strings.Split(strings.Trim(resource, "/"), "/") {
+		if !strings.HasPrefix(segment, "{") {
+			count++
+		}
+	}
+	return count
+}
+
+func firstValues(values map[string][]string) map[string]string {
+	first := make(map[string]string, len(values))
+	for key, value := range values {
+		if len(value) > 0 {
+			first[key] = value[0]
+		}
+	}
+	return first
+}
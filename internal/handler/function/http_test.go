@@ -0,0 +1,83 @@
+package function
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+	mock_todo "github.com/BrunoDM2943/go-todo-lambda/internal/module/todo/mock"
+)
+
+func TestHTTPHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mock_todo.NewMockService(ctrl)
+	handler := NewLambdaHandler(mockService)
+	handler.BuildRoutes()
+	server := httptest.NewServer(NewHTTPHandler(handler))
+	defer server.Close()
+
+	t.Run("Routes path parameters", func(t *testing.T) {
+		mockService.EXPECT().GetItem(gomock.Any(), gomock.Eq("a b")).Return(&model.Item{ID: "a b"}, nil)
+
+		response, err := http.Get(server.URL + "/todo-api/a%20b")
+		assert.Nil(t, err)
+		defer response.Body.Close()
+		body, _ := ioutil.ReadAll(response.Body)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, contentTypeJSON, response.Header.Get("Content-Type"))
+		assert.Contains(t, string(body), `"ID":"a b"`)
+	})
+
+	t.Run("Routes nested resources", func(t *testing.T) {
+		mockService.EXPECT().CompleteItem(gomock.Any(), gomock.Eq(defaultID)).Return(&model.Item{ID: defaultID}, nil)
+
+		response, err := http.Post(server.URL+"/todo-api/"+defaultID+"/complete", "application/json", nil)
+		assert.Nil(t, err)
+		response.Body.Close()
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("Forwards query and body", func(t *testing.T) {
+		mockService.EXPECT().GetItems(gomock.Any(), gomock.Eq(model.ListQuery{Limit: 5, Filter: model.ItemFilter{Status: model.StatusOpen, Archived: &unarchived}})).Return(&model.Page{}, nil)
+
+		response, err := http.Get(server.URL + "/todo-api?limit=5&status=open")
+		assert.Nil(t, err)
+		response.Body.Close()
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		mockService.EXPECT().PostItem(gomock.Any(), gomock.Eq(&model.Item{Title: "List", Text: "Homework"})).Return(&model.Item{ID: defaultID}, nil)
+
+		response, err = http.Post(server.URL+"/todo-api", "application/json", strings.NewReader(`{"title":"List","text":"Homework"}`))
+		assert.Nil(t, err)
+		response.Body.Close()
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+		assert.Equal(t, "/todo-api/"+defaultID, response.Header.Get("Location"))
+	})
+
+	t.Run("Unknown path", func(t *testing.T) {
+		response, err := http.Get(server.URL + "/unknown")
+		assert.Nil(t, err)
+		response.Body.Close()
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	})
+}
+
+func TestMatchResource(t *testing.T) {
+	parameters, ok := matchResource("/todo-api/{id}/complete", "/todo-api/xpto/complete")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"id": "xpto"}, parameters)
+
+	_, ok = matchResource("/todo-api/{id}", "/todo-api/xpto/complete")
+	assert.False(t, ok)
+
+	_, ok = matchResource("/todo-api/{id}", "/todo-api/")
+	assert.False(t, ok)
+}
@@ -4,112 +4,456 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+	"github.com/BrunoDM2943/go-todo-lambda/internal/logging"
+	"github.com/BrunoDM2943/go-todo-lambda/internal/metrics"
+	"github.com/BrunoDM2943/go-todo-lambda/internal/module/lists"
 	"github.com/BrunoDM2943/go-todo-lambda/internal/module/todo"
 	"github.com/aws/aws-lambda-go/events"
 )
 
-type lambdaHandler struct {
+type LambdaHandler struct {
 	todoService todo.Service
+	listService lists.Service
 	routes      map[string]handleFunc
+	logger      *logging.Logger
+	metrics     metrics.Recorder
 }
 
-type handleFunc func(events.APIGatewayProxyRequest) events.APIGatewayProxyResponse
+type Option func(*LambdaHandler)
 
-var successResponse = events.APIGatewayProxyResponse{
-	StatusCode: http.StatusOK,
+func WithLogger(logger *logging.Logger) Option {
+	return func(handler *LambdaHandler) {
+		handler.logger = logger
+	}
 }
 
-var createdResponse = events.APIGatewayProxyResponse{
-	StatusCode: http.StatusCreated,
+func WithMetrics(recorder metrics.Recorder) Option {
+	return func(handler *LambdaHandler) {
+		handler.metrics = recorder
+	}
 }
 
-func (handler *lambdaHandler) BuildRoutes() {
+type handleFunc func(context.Context, events.APIGatewayProxyRequest) events.APIGatewayProxyResponse
+
+func (handler *LambdaHandler) BuildRoutes() {
 	handler.routes = map[string]handleFunc{
-		"GET:/todo-api":         handler.getAllItems,
-		"POST:/todo-api":        handler.postHandler,
-		"GET:/todo-api/{id}":    handler.getItem,
-		"DELETE:/todo-api/{id}": handler.deleteHandler,
+		"GET:/todo-api":                                  handler.getAllItems,
+		"POST:/todo-api":                                 handler.postHandler,
+		"GET:/todo-api/overdue":                          handler.getOverdueItems,
+		"GET:/todo-api/upcoming":                         handler.getUpcomingItems,
+		"GET:/todo-api/next":                             handler.getNextItems,
+		"GET:/todo-api/tags":                             handler.getTags,
+		"POST:/todo-api/tags/merge":                      handler.mergeTagsHandler,
+		"POST:/todo-api/tags/{tag}/rename":               handler.renameTagHandler,
+		"GET:/todo-api/{id}":                             handler.getItem,
+		"PUT:/todo-api/{id}":                             handler.putHandler,
+		"PATCH:/todo-api/{id}":                           handler.patchHandler,
+		"DELETE:/todo-api/{id}":                          handler.deleteHandler,
+		"POST:/todo-api/{id}/complete":                   handler.completeHandler,
+		"POST:/todo-api/{id}/reopen":                     handler.reopenHandler,
+		"POST:/todo-api/{id}/checklist":                  handler.addChecklistEntryHandler,
+		"POST:/todo-api/{id}/checklist/{entryId}/toggle": handler.toggleChecklistEntryHandler,
+		"POST:/todo-api/{id}/checklist/{entryId}/move":   handler.moveChecklistEntryHandler,
+		"DELETE:/todo-api/{id}/checklist/{entryId}":      handler.removeChecklistEntryHandler,
+		"POST:/todo-api/{id}/dependencies":               handler.addDependencyHandler,
+		"DELETE:/todo-api/{id}/dependencies/{blockerId}": handler.removeDependencyHandler,
+	}
+	if handler.listService != nil {
+		handler.buildListRoutes()
 	}
 }
 
-func NewLambdaHandler(todoService todo.Service) *lambdaHandler {
-	return &lambdaHandler{
+func NewLambdaHandler(todoService todo.Service, options ...Option) *LambdaHandler {
+	handler := &LambdaHandler{
 		todoService: todoService,
+		logger:      logging.New(os.Stderr, logging.LevelInfo),
+		metrics:     metrics.Nop(),
 	}
+	for _, option := range options {
+		option(handler)
+	}
+	return handler
 }
 
-func (handler *lambdaHandler) HandleRequest(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+func (handler *LambdaHandler) HandleRequest(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	started := time.Now()
 	key := fmt.Sprintf("%s:%s", request.HTTPMethod, request.Resource)
-	log.Printf("Receiving the following request: %s", key)
+	handler.logger.Debugf("Receiving the following request: %s", key)
 	functionHandler := handler.routes[key]
 	var response events.APIGatewayProxyResponse
 	if functionHandler == nil {
-		response = buildErrorResponse("Not implemented", http.StatusNotImplemented)
+		response = buildProblemResponse(request, http.StatusNotImplemented, "Not implemented")
 	} else {
-		response = functionHandler(request)
+		response = functionHandler(ctx, request)
 	}
+	handler.metrics.ObserveRequest(key, response.StatusCode, time.Since(started))
 	return response, nil
 }
 
-func (handler *lambdaHandler) deleteHandler(request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+func (handler *LambdaHandler) deleteHandler(ctx context.Context, request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
 	id := request.PathParameters["id"]
 	if id == "" {
-		return buildErrorResponse("Invalid ID", http.StatusBadRequest)
+		return buildProblemResponse(request, http.StatusBadRequest, "Invalid ID")
+	}
+	version, failed := expectedVersion(request)
+	if failed != nil {
+		return *failed
 	}
-	if err := handler.todoService.DeleteItem(id); err != nil {
-		return buildErrorResponse(err.Error(), http.StatusInternalServerError)
+	if err := handler.todoService.DeleteItem(ctx, id, version); err != nil {
+		return handler.buildErrorResponse(request, err)
 	}
-	return successResponse
+	return buildEmptyResponse(http.StatusOK)
 }
 
-func (handler *lambdaHandler) postHandler(request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
-	item := &model.Item{}
-	_ = json.Unmarshal([]byte(request.Body), item)
+func (handler *LambdaHandler) postHandler(ctx context.Context, request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	item, invalid := decodeItem(request.Body)
+	if invalid != "" {
+		return buildProblemResponse(request, http.StatusBadRequest, invalid)
+	}
+	// Items join a list through the list routes, which check it exists.
+	item.ListID = ""
+	item.Archived = false
+	created, err := handler.todoService.PostItem(ctx, item)
+	if err != nil {
+		return handler.buildErrorResponse(request, err)
+	}
+	return buildCreatedResponse(fmt.Sprintf("/todo-api/%s", created.ID), created)
+}
 
-	if item.Title == "" || item.Text == "" {
-		return buildErrorResponse("Invalid body", http.StatusBadRequest)
+func (handler *LambdaHandler) putHandler(ctx context.Context, request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	id := request.PathParameters["id"]
+	if id == "" {
+		return buildProblemResponse(request, http.StatusBadRequest, "Invalid ID")
 	}
-	if err := handler.todoService.PostItem(item); err != nil {
-		return buildErrorResponse(err.Error(), http.StatusInternalServerError)
+	version, failed := expectedVersion(request)
+	if failed != nil {
+		return *failed
 	}
-	return createdResponse
+	item, invalid := decodeItem(request.Body)
+	if invalid != "" {
+		return buildProblemResponse(request, http.StatusBadRequest, invalid)
+	}
+	updated, err := handler.todoService.UpdateItem(ctx, id, version, item)
+	if err != nil {
+		return handler.buildErrorResponse(request, err)
+	}
+	return buildItemResponse(updated)
 }
 
-func (handler *lambdaHandler) getAllItems(request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
-	items, err := handler.todoService.GetItems()
+func (handler *LambdaHandler) patchHandler(ctx context.Context, request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	id := request.PathParameters["id"]
+	if id == "" {
+		return buildProblemResponse(request, http.StatusBadRequest, "Invalid ID")
+	}
+	if contentType := headerValue(request, "Content-Type"); contentType != "" &&
+		!strings.HasPrefix(contentType, "application/merge-patch+json") &&
+		!strings.HasPrefix(contentType, "application/json") {
+		return buildProblemResponse(request, http.StatusUnsupportedMediaType, "Unsupported content type")
+	}
+	version, failed := expectedVersion(request)
+	if failed != nil {
+		return *failed
+	}
+	patch := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(request.Body), &patch); err != nil {
+		return buildProblemResponse(request, http.StatusBadRequest, "Invalid body")
+	}
+	item, err := handler.todoService.PatchItem(ctx, id, version, patch)
 	if err != nil {
-		return buildErrorResponse(err.Error(), http.StatusInternalServerError)
+		return handler.buildErrorResponse(request, err)
 	}
-	body, _ := json.Marshal(items)
-	return buildSuccessResponse(string(body))
+	return buildItemResponse(item)
+}
+
+func (handler *LambdaHandler) completeHandler(ctx context.Context, request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	return handler.transitionItem(ctx, request, handler.todoService.CompleteItem)
+}
+
+func (handler *LambdaHandler) reopenHandler(ctx context.Context, request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	return handler.transitionItem(ctx, request, handler.todoService.ReopenItem)
 }
 
-func (handler *lambdaHandler) getItem(request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+// transitionItem serves the routes that change an item's state. Like the
+// other sub-routes of an item they take no If-Match: the service writes
+// against the version it has just read and answers 409 Conflict when another
+// write got in between.
+func (handler *LambdaHandler) transitionItem(ctx context.Context, request events.APIGatewayProxyRequest, transition func(ctx context.Context, id string) (*model.Item, error)) events.APIGatewayProxyResponse {
 	id := request.PathParameters["id"]
-	item, err := handler.todoService.GetItem(id)
-	if item == nil {
-		return buildErrorResponse(fmt.Sprintf("ID %s not found", id), http.StatusNotFound)
-	} else if err != nil {
-		return buildErrorResponse(err.Error(), http.StatusInternalServerError)
+	if id == "" {
+		return buildProblemResponse(request, http.StatusBadRequest, "Invalid ID")
+	}
+	item, err := transition(ctx, id)
+	if err != nil {
+		return handler.buildErrorResponse(request, err)
+	}
+	return buildItemResponse(item)
+}
+
+func (handler *LambdaHandler) getAllItems(ctx context.Context, request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	query, invalid := parseItemQuery(request)
+	if invalid != "" {
+		return buildProblemResponse(request, http.StatusBadRequest, invalid)
+	}
+	page, err := handler.todoService.GetItems(ctx, query)
+	if err != nil {
+		return handler.buildErrorResponse(request, err)
+	}
+	return buildPageResponse(request, page)
+}
+
+func (handler *LambdaHandler) getOverdueItems(ctx context.Context, request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	query, ok := parsePaging(request)
+	if !ok {
+		return buildProblemResponse(request, http.StatusBadRequest, "Invalid limit")
+	}
+	page, err := handler.todoService.GetOverdueItems(ctx, query)
+	if err != nil {
+		return handler.buildErrorResponse(request, err)
+	}
+	return buildPageResponse(request, page)
+}
+
+func (handler *LambdaHandler) getUpcomingItems(ctx context.Context, request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	query, ok := parsePaging(request)
+	if !ok {
+		return buildProblemResponse(request, http.StatusBadRequest, "Invalid limit")
+	}
+	var within time.Duration
+	if value := request.QueryStringParameters["within"]; value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return buildProblemResponse(request, http.StatusBadRequest, "Invalid within, expected a duration such as 48h")
+		}
+		within = parsed
+	}
+	page, err := handler.todoService.GetUpcomingItems(ctx, within, query)
+	if err != nil {
+		return handler.buildErrorResponse(request, err)
+	}
+	return buildPageResponse(request, page)
+}
+
+func (handler *LambdaHandler) getNextItems(ctx context.Context, request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	query, ok := parsePaging(request)
+	if !ok {
+		return buildProblemResponse(request, http.StatusBadRequest, "Invalid limit")
+	}
+	// The ranking is computed over all open items on each call, so there is no
+	// position a cursor could resume from.
+	if query.Cursor != "" {
+		return handler.buildErrorResponse(request, fmt.Errorf("%w: the next items take no cursor", todo.ErrValidation))
+	}
+	page, err := handler.todoService.GetNextItems(ctx, query.Limit)
+	if err != nil {
+		return handler.buildErrorResponse(request, err)
+	}
+	return buildPageResponse(request, page)
+}
+
+func (handler *LambdaHandler) getTags(ctx context.Context, request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	counts, err := handler.todoService.GetTags(ctx)
+	if err != nil {
+		return handler.buildErrorResponse(request, err)
 	}
-	body, _ := json.Marshal(item)
+	body, _ := json.Marshal(map[string]interface{}{"tags": counts})
 	return buildSuccessResponse(string(body))
 }
 
-func buildErrorResponse(message string, statusCode int) events.APIGatewayProxyResponse {
-	return events.APIGatewayProxyResponse{
-		Body:       fmt.Sprintf(`{"message":"%s"}`, message),
-		StatusCode: statusCode,
+func (handler *LambdaHandler) renameTagHandler(ctx context.Context, request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	tag := request.PathParameters["tag"]
+	if tag == "" {
+		return buildProblemResponse(request, http.StatusBadRequest, "Invalid tag")
+	}
+	body := struct {
+		Name string `json:"name"`
+	}{}
+	if err := json.Unmarshal([]byte(request.Body), &body); err != nil || body.Name == "" {
+		return buildProblemResponse(request, http.StatusBadRequest, "Invalid body, expected the new tag name")
+	}
+	changed, err := handler.todoService.RenameTag(ctx, tag, body.Name)
+	if err != nil {
+		return handler.buildErrorResponse(request, err)
+	}
+	return buildRetagResponse(changed)
+}
+
+func (handler *LambdaHandler) mergeTagsHandler(ctx context.Context, request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	body := struct {
+		Tags []string `json:"tags"`
+		Into string   `json:"into"`
+	}{}
+	if err := json.Unmarshal([]byte(request.Body), &body); err != nil || len(body.Tags) == 0 || body.Into == "" {
+		return buildProblemResponse(request, http.StatusBadRequest, "Invalid body, expected the tags to merge and the tag to merge them into")
+	}
+	changed, err := handler.todoService.MergeTags(ctx, body.Tags, body.Into)
+	if err != nil {
+		return handler.buildErrorResponse(request, err)
+	}
+	return buildRetagResponse(changed)
+}
+
+func buildRetagResponse(changed int) events.APIGatewayProxyResponse {
+	body, _ := json.Marshal(map[string]int{"updated": changed})
+	return buildSuccessResponse(string(body))
+}
+
+// parseItemQuery reads the paging, filters and sort order of an item
+// listing. Archived items are left out unless archived=true asks for them;
+// blocked=true or false narrows the listing to items that wait on others or
+// do not. due_after (inclusive) and due_before (exclusive) take RFC 3339
+// timestamps.
+func parseItemQuery(request events.APIGatewayProxyRequest) (model.ListQuery, string) {
+	query, ok := parsePaging(request)
+	if !ok {
+		return query, "Invalid limit"
+	}
+	archived := false
+	if value := request.QueryStringParameters["archived"]; value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return query, "Invalid archived, expected true or false"
+		}
+		archived = parsed
+	}
+	var blocked *bool
+	if value := request.QueryStringParameters["blocked"]; value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return query, "Invalid blocked, expected true or false"
+		}
+		blocked = &parsed
+	}
+	dueAfter, ok := parseTimeParameter(request, "due_after")
+	if !ok {
+		return query, "Invalid due_after, expected an RFC 3339 timestamp"
+	}
+	dueBefore, ok := parseTimeParameter(request, "due_before")
+	if !ok {
+		return query, "Invalid due_before, expected an RFC 3339 timestamp"
+	}
+	query.Filter = model.ItemFilter{
+		Status:    request.QueryStringParameters["status"],
+		Query:     request.QueryStringParameters["q"],
+		Tags:      queryValues(request, "tag"),
+		TagMatch:  request.QueryStringParameters["tag_match"],
+		Archived:  &archived,
+		Blocked:   blocked,
+		DueAfter:  dueAfter,
+		DueBefore: dueBefore,
+	}
+	query.Sort = parseSortOrder(request.QueryStringParameters["sort"])
+	return query, ""
+}
+
+// parseTimeParameter reads an optional RFC 3339 query parameter as a UTC
+// instant.
+func parseTimeParameter(request events.APIGatewayProxyRequest, name string) (*time.Time, bool) {
+	value := request.QueryStringParameters[name]
+	if value == "" {
+		return nil, true
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, false
+	}
+	parsed = parsed.UTC()
+	return &parsed, true
+}
+
+func parsePaging(request events.APIGatewayProxyRequest) (model.ListQuery, bool) {
+	query := model.ListQuery{
+		Cursor: request.QueryStringParameters["cursor"],
+	}
+	if limit := request.QueryStringParameters["limit"]; limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil || parsed < 1 {
+			return query, false
+		}
+		query.Limit = parsed
+	}
+	return query, true
+}
+
+func buildPageResponse(request events.APIGatewayProxyRequest, page *model.Page) events.APIGatewayProxyResponse {
+	body, _ := json.Marshal(newPageView(page))
+	// Deleting an item leaves the newest UpdatedAt as it was, so pages are
+	// only validated by their content.
+	etag := contentETag(string(body))
+	if notModified(request, etag, nil) {
+		return buildNotModifiedResponse(etag, nil)
+	}
+	response := buildSuccessResponse(string(body))
+	setValidators(response.Headers, etag, nil)
+	return response
+}
+
+func (handler *LambdaHandler) getItem(ctx context.Context, request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	id := request.PathParameters["id"]
+	item, err := handler.todoService.GetItem(ctx, id)
+	if err != nil {
+		return handler.buildErrorResponse(request, err)
+	}
+	if etag := formatETag(item.Version); notModified(request, etag, item.UpdatedAt) {
+		return buildNotModifiedResponse(etag, item.UpdatedAt)
+	}
+	return buildItemResponse(item)
+}
+
+// decodeItem reads an item body and returns a problem detail when it is not
+// acceptable. Due dates must carry their time zone, which RFC 3339 requires.
+func decodeItem(body string) (*model.Item, string) {
+	item := &model.Item{}
+	payload := struct {
+		*model.Item
+		DueAt *string `json:"dueAt"`
+	}{Item: item}
+	if err := json.Unmarshal([]byte(body), &payload); err != nil {
+		return nil, "Invalid body"
+	}
+	if item.Title == "" || item.Text == "" {
+		return nil, "Invalid body"
+	}
+	if payload.DueAt != nil {
+		dueAt, err := time.Parse(time.RFC3339, *payload.DueAt)
+		if err != nil {
+			return nil, "Invalid dueAt, expected an RFC 3339 timestamp with a time zone such as 2021-03-20T18:00:00-03:00"
+		}
+		item.DueAt = &dueAt
+	}
+	return item, ""
+}
+
+func parseSortOrder(value string) model.SortOrder {
+	if strings.HasPrefix(value, "-") {
+		return model.SortOrder{Field: value[1:], Descending: true}
+	}
+	return model.SortOrder{Field: value}
+}
+
+// queryValues reads a repeatable query parameter. API Gateway only fills the
+// multi-value map when the request actually carried query parameters.
+func queryValues(request events.APIGatewayProxyRequest, name string) []string {
+	if values := request.MultiValueQueryStringParameters[name]; len(values) > 0 {
+		return values
+	}
+	if value, ok := request.QueryStringParameters[name]; ok {
+		return []string{value}
 	}
+	return nil
 }
 
-func buildSuccessResponse(body string) events.APIGatewayProxyResponse {
-	return events.APIGatewayProxyResponse{
-		Body:       body,
-		StatusCode: http.StatusOK,
+func headerValue(request events.APIGatewayProxyRequest, name string) string {
+	for key, value := range request.Headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
 	}
+	return ""
 }
@@ -8,8 +8,10 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+	"github.com/BrunoDM2943/go-todo-lambda/internal/module/todo"
 	mock_todo "github.com/BrunoDM2943/go-todo-lambda/internal/module/todo/mock"
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/stretchr/testify/assert"
@@ -17,6 +19,9 @@ import (
 
 const defaultID = "xpto"
 
+// unarchived is the archived filter item listings apply by default.
+var unarchived = false
+
 func TestGetHandler(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -27,7 +32,7 @@ func TestGetHandler(t *testing.T) {
 
 	t.Run("Test Get for one ID", func(t *testing.T) {
 
-		mockService.EXPECT().GetItem(gomock.Eq(defaultID)).Return(&model.Item{}, nil)
+		mockService.EXPECT().GetItem(gomock.Any(), gomock.Eq(defaultID)).Return(&model.Item{}, nil)
 
 		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
 			HTTPMethod: "GET",
@@ -39,9 +44,27 @@ func TestGetHandler(t *testing.T) {
 		assert.Equal(t, http.StatusOK, response.StatusCode)
 	})
 
+	t.Run("Test Get for one ID passes the context on", func(t *testing.T) {
+
+		mockService.EXPECT().GetItem(gomock.Any(), gomock.Eq(defaultID)).DoAndReturn(func(ctx context.Context, id string) (*model.Item, error) {
+			return nil, ctx.Err()
+		})
+
+		canceled, cancel := context.WithCancel(context.TODO())
+		cancel()
+		response, _ := handler.HandleRequest(canceled, events.APIGatewayProxyRequest{
+			HTTPMethod: "GET",
+			Resource:   "/todo-api/{id}",
+			PathParameters: map[string]string{
+				"id": defaultID,
+			},
+		})
+		assert.Equal(t, http.StatusInternalServerError, response.StatusCode)
+	})
+
 	t.Run("Test Get for one ID with error", func(t *testing.T) {
 
-		mockService.EXPECT().GetItem(gomock.Eq(defaultID)).Return(&model.Item{}, errors.New("Error"))
+		mockService.EXPECT().GetItem(gomock.Any(), gomock.Eq(defaultID)).Return(&model.Item{}, errors.New("Error"))
 
 		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
 			HTTPMethod: "GET",
@@ -55,7 +78,7 @@ func TestGetHandler(t *testing.T) {
 
 	t.Run("Test Get for one ID not found", func(t *testing.T) {
 
-		mockService.EXPECT().GetItem(gomock.Eq(defaultID)).Return(nil, nil)
+		mockService.EXPECT().GetItem(gomock.Any(), gomock.Eq(defaultID)).Return(nil, todo.ErrNotFound)
 
 		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
 			HTTPMethod: "GET",
@@ -81,7 +104,7 @@ func TestGetHandler(t *testing.T) {
 
 	t.Run("Test Get for all ID - OK", func(t *testing.T) {
 
-		mockService.EXPECT().GetItems().Return([]*model.Item{}, nil)
+		mockService.EXPECT().GetItems(gomock.Any(), gomock.Eq(model.ListQuery{Filter: model.ItemFilter{Archived: &unarchived}})).Return(&model.Page{}, nil)
 
 		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
 			HTTPMethod: "GET",
@@ -95,7 +118,7 @@ func TestGetHandler(t *testing.T) {
 
 	t.Run("Test Get for all ID - Error", func(t *testing.T) {
 
-		mockService.EXPECT().GetItems().Return(nil, errors.New("Error"))
+		mockService.EXPECT().GetItems(gomock.Any(), gomock.Any()).Return(nil, errors.New("Error"))
 
 		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
 			HTTPMethod: "GET",
@@ -106,6 +129,281 @@ func TestGetHandler(t *testing.T) {
 		})
 		assert.Equal(t, http.StatusInternalServerError, response.StatusCode)
 	})
+
+	t.Run("Test Get for all ID - Pagination", func(t *testing.T) {
+
+		mockService.EXPECT().GetItems(gomock.Any(), gomock.Eq(model.ListQuery{Limit: 10, Cursor: "next", Filter: model.ItemFilter{Archived: &unarchived}})).Return(&model.Page{NextCursor: "after"}, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "GET",
+			Resource:   "/todo-api",
+			QueryStringParameters: map[string]string{
+				"limit":  "10",
+				"cursor": "next",
+			},
+		})
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.JSONEq(t, `{"items":null,"next_cursor":"after"}`, response.Body)
+	})
+
+	t.Run("Test Get for all ID - Filter and sort", func(t *testing.T) {
+
+		mockService.EXPECT().GetItems(gomock.Any(), gomock.Eq(model.ListQuery{
+			Filter: model.ItemFilter{Status: model.StatusDone, Query: "home", Archived: &unarchived},
+			Sort:   model.SortOrder{Field: model.SortByTitle, Descending: true},
+		})).Return(&model.Page{}, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "GET",
+			Resource:   "/todo-api",
+			QueryStringParameters: map[string]string{
+				"status": "done",
+				"q":      "home",
+				"sort":   "-title",
+			},
+		})
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("Test Get for all ID - Invalid limit", func(t *testing.T) {
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "GET",
+			Resource:   "/todo-api",
+			QueryStringParameters: map[string]string{
+				"limit": "-1",
+			},
+		})
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("Test Get for all ID - Invalid cursor", func(t *testing.T) {
+
+		mockService.EXPECT().GetItems(gomock.Any(), gomock.Any()).Return(nil, todo.ErrValidation)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "GET",
+			Resource:   "/todo-api",
+			QueryStringParameters: map[string]string{
+				"cursor": "forged",
+			},
+		})
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+}
+
+func TestDueHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mock_todo.NewMockService(ctrl)
+	handler := NewLambdaHandler(mockService)
+	handler.BuildRoutes()
+
+	t.Run("Test Get Overdue Items - OK", func(t *testing.T) {
+		mockService.EXPECT().GetOverdueItems(gomock.Any(), model.ListQuery{Limit: 5, Cursor: "next"}).Return(&model.Page{NextCursor: "after"}, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:            "GET",
+			Resource:              "/todo-api/overdue",
+			QueryStringParameters: map[string]string{"limit": "5", "cursor": "next"},
+		})
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.JSONEq(t, `{"items":null,"next_cursor":"after"}`, response.Body)
+		assert.NotEmpty(t, response.Headers["ETag"])
+	})
+
+	t.Run("Test Get Items - Due bounds", func(t *testing.T) {
+		after := time.Date(2021, 3, 20, 21, 0, 0, 0, time.UTC)
+		before := time.Date(2021, 3, 27, 0, 0, 0, 0, time.UTC)
+		mockService.EXPECT().GetItems(gomock.Any(), model.ListQuery{
+			Filter: model.ItemFilter{Archived: &unarchived, DueAfter: &after, DueBefore: &before},
+		}).Return(&model.Page{}, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "GET",
+			Resource:   "/todo-api",
+			QueryStringParameters: map[string]string{
+				"due_after":  "2021-03-20T18:00:00-03:00",
+				"due_before": "2021-03-27T00:00:00Z",
+			},
+		})
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("Test Get Items - Invalid due bound", func(t *testing.T) {
+		for _, parameter := range []string{"due_after", "due_before"} {
+			response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+				HTTPMethod:            "GET",
+				Resource:              "/todo-api",
+				QueryStringParameters: map[string]string{parameter: "2021-03-20"},
+			})
+			assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+		}
+	})
+
+	t.Run("Test Get Next Items - OK", func(t *testing.T) {
+		mockService.EXPECT().GetNextItems(gomock.Any(), 3).Return(&model.Page{Items: []*model.Item{{ID: defaultID, Priority: model.PriorityP0}}}, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:            "GET",
+			Resource:              "/todo-api/next",
+			QueryStringParameters: map[string]string{"limit": "3"},
+		})
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.JSONEq(t, `{"items":[{"ID":"xpto","title":"","text":"","done":false,"priority":"P0","version":0}]}`, response.Body)
+	})
+
+	t.Run("Test Get Next Items - Invalid limit", func(t *testing.T) {
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:            "GET",
+			Resource:              "/todo-api/next",
+			QueryStringParameters: map[string]string{"limit": "none"},
+		})
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("Test Get Next Items - Cursor", func(t *testing.T) {
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:            "GET",
+			Resource:              "/todo-api/next",
+			QueryStringParameters: map[string]string{"limit": "3", "cursor": "next"},
+		})
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("Test Get Upcoming Items - OK", func(t *testing.T) {
+		mockService.EXPECT().GetUpcomingItems(gomock.Any(), 48*time.Hour, model.ListQuery{}).Return(&model.Page{}, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:            "GET",
+			Resource:              "/todo-api/upcoming",
+			QueryStringParameters: map[string]string{"within": "48h"},
+		})
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("Test Get Upcoming Items - Default window", func(t *testing.T) {
+		mockService.EXPECT().GetUpcomingItems(gomock.Any(), time.Duration(0), model.ListQuery{}).Return(&model.Page{}, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "GET",
+			Resource:   "/todo-api/upcoming",
+		})
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("Test Get Upcoming Items - Invalid window", func(t *testing.T) {
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:            "GET",
+			Resource:              "/todo-api/upcoming",
+			QueryStringParameters: map[string]string{"within": "two days"},
+		})
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("Test Get Upcoming Items - Window too long", func(t *testing.T) {
+		mockService.EXPECT().GetUpcomingItems(gomock.Any(), 8760*time.Hour, model.ListQuery{}).Return(nil, todo.ErrValidation)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:            "GET",
+			Resource:              "/todo-api/upcoming",
+			QueryStringParameters: map[string]string{"within": "8760h"},
+		})
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+}
+
+func TestTagHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mock_todo.NewMockService(ctrl)
+	handler := NewLambdaHandler(mockService)
+	handler.BuildRoutes()
+
+	t.Run("Test Get Items by tags", func(t *testing.T) {
+		mockService.EXPECT().GetItems(gomock.Any(), model.ListQuery{
+			Filter: model.ItemFilter{Tags: []string{"work", "urgent"}, TagMatch: model.TagMatchAny, Archived: &unarchived},
+		}).Return(&model.Page{}, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:                      "GET",
+			Resource:                        "/todo-api",
+			QueryStringParameters:           map[string]string{"tag": "urgent", "tag_match": "any"},
+			MultiValueQueryStringParameters: map[string][]string{"tag": {"work", "urgent"}, "tag_match": {"any"}},
+		})
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("Test Get Tags - OK", func(t *testing.T) {
+		mockService.EXPECT().GetTags(gomock.Any()).Return([]model.TagCount{{Name: "work", Count: 2}}, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "GET",
+			Resource:   "/todo-api/tags",
+		})
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.JSONEq(t, `{"tags":[{"name":"work","count":2}]}`, response.Body)
+	})
+
+	t.Run("Test Rename Tag - OK", func(t *testing.T) {
+		mockService.EXPECT().RenameTag(gomock.Any(), "home", "chores").Return(3, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:     "POST",
+			Resource:       "/todo-api/tags/{tag}/rename",
+			PathParameters: map[string]string{"tag": "home"},
+			Body:           `{"name":"chores"}`,
+		})
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.JSONEq(t, `{"updated":3}`, response.Body)
+	})
+
+	t.Run("Test Rename Tag - Conflict", func(t *testing.T) {
+		mockService.EXPECT().RenameTag(gomock.Any(), "home", "chores").Return(0, todo.ErrConflict)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:     "POST",
+			Resource:       "/todo-api/tags/{tag}/rename",
+			PathParameters: map[string]string{"tag": "home"},
+			Body:           `{"name":"chores"}`,
+		})
+		assert.Equal(t, http.StatusConflict, response.StatusCode)
+	})
+
+	t.Run("Test Rename Tag - BadRequest", func(t *testing.T) {
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:     "POST",
+			Resource:       "/todo-api/tags/{tag}/rename",
+			PathParameters: map[string]string{"tag": "home"},
+			Body:           `{}`,
+		})
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("Test Merge Tags - OK", func(t *testing.T) {
+		mockService.EXPECT().MergeTags(gomock.Any(), []string{"home", "chores"}, "house").Return(2, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "POST",
+			Resource:   "/todo-api/tags/merge",
+			Body:       `{"tags":["home","chores"],"into":"house"}`,
+		})
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.JSONEq(t, `{"updated":2}`, response.Body)
+	})
+
+	t.Run("Test Merge Tags - Not found", func(t *testing.T) {
+		mockService.EXPECT().MergeTags(gomock.Any(), []string{"home"}, "house").Return(0, todo.ErrNotFound)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "POST",
+			Resource:   "/todo-api/tags/merge",
+			Body:       `{"tags":["home"],"into":"house"}`,
+		})
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	})
 }
 
 func TestDeleteHandler(t *testing.T) {
@@ -118,11 +416,12 @@ func TestDeleteHandler(t *testing.T) {
 
 	t.Run("Test Delete ID - OK", func(t *testing.T) {
 
-		mockService.EXPECT().DeleteItem(gomock.Eq(defaultID)).Return(nil)
+		mockService.EXPECT().DeleteItem(gomock.Any(), gomock.Eq(defaultID), gomock.Eq(int64(1))).Return(nil)
 
 		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
 			HTTPMethod: "DELETE",
 			Resource:   "/todo-api/{id}",
+			Headers:    map[string]string{"If-Match": `"1"`},
 			PathParameters: map[string]string{
 				"id": defaultID,
 			},
@@ -132,11 +431,12 @@ func TestDeleteHandler(t *testing.T) {
 
 	t.Run("Test Delete ID - Error", func(t *testing.T) {
 
-		mockService.EXPECT().DeleteItem(gomock.Eq(defaultID)).Return(errors.New("Error"))
+		mockService.EXPECT().DeleteItem(gomock.Any(), gomock.Eq(defaultID), gomock.Eq(int64(1))).Return(errors.New("Error"))
 
 		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
 			HTTPMethod: "DELETE",
 			Resource:   "/todo-api/{id}",
+			Headers:    map[string]string{"If-Match": `"1"`},
 			PathParameters: map[string]string{
 				"id": defaultID,
 			},
@@ -144,11 +444,27 @@ func TestDeleteHandler(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, response.StatusCode)
 	})
 
+	t.Run("Test Delete ID - Not found", func(t *testing.T) {
+
+		mockService.EXPECT().DeleteItem(gomock.Any(), gomock.Eq(defaultID), gomock.Eq(int64(1))).Return(todo.ErrNotFound)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "DELETE",
+			Resource:   "/todo-api/{id}",
+			Headers:    map[string]string{"If-Match": `"1"`},
+			PathParameters: map[string]string{
+				"id": defaultID,
+			},
+		})
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	})
+
 	t.Run("Test Delete ID - Bad Request", func(t *testing.T) {
 
 		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
 			HTTPMethod: "DELETE",
 			Resource:   "/todo-api/{id}",
+			Headers:    map[string]string{"If-Match": `"1"`},
 			PathParameters: map[string]string{
 				"id": "",
 			},
@@ -170,7 +486,7 @@ func TestPostHandler(t *testing.T) {
 			Title: "List",
 			Text:  "Homework",
 		}
-		mockService.EXPECT().PostItem(gomock.Eq(item)).Return(nil)
+		mockService.EXPECT().PostItem(gomock.Any(), gomock.Eq(item)).Return(&model.Item{ID: defaultID, Title: "List", Text: "Homework", Version: 1}, nil)
 
 		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
 			HTTPMethod: "POST",
@@ -178,6 +494,9 @@ func TestPostHandler(t *testing.T) {
 			Body:       `{"title": "List", "text":"Homework"}`,
 		})
 		assert.Equal(t, http.StatusCreated, response.StatusCode)
+		assert.Equal(t, "/todo-api/"+defaultID, response.Headers["Location"])
+		assert.Equal(t, `"1"`, response.Headers["ETag"])
+		assert.JSONEq(t, `{"ID":"xpto","title":"List","text":"Homework","done":false,"version":1}`, response.Body)
 	})
 
 	t.Run("Test Post Item - BadRequest ", func(t *testing.T) {
@@ -189,12 +508,46 @@ func TestPostHandler(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
 	})
 
+	t.Run("Test Post Item - Due date", func(t *testing.T) {
+		dueAt := time.Date(2021, 3, 20, 18, 0, 0, 0, time.FixedZone("", -3*60*60))
+		mockService.EXPECT().PostItem(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, item *model.Item) (*model.Item, error) {
+			assert.True(t, dueAt.Equal(*item.DueAt))
+			return &model.Item{ID: defaultID, Title: "List", Text: "Homework", DueAt: item.DueAt, Version: 1}, nil
+		})
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "POST",
+			Resource:   "/todo-api",
+			Body:       `{"title": "List", "text":"Homework", "dueAt":"2021-03-20T18:00:00-03:00"}`,
+		})
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+	})
+
+	t.Run("Test Post Item - Due date without time zone", func(t *testing.T) {
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "POST",
+			Resource:   "/todo-api",
+			Body:       `{"title": "List", "text":"Homework", "dueAt":"2021-03-20T18:00:00"}`,
+		})
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+		assert.Contains(t, response.Body, "dueAt")
+	})
+
+	t.Run("Test Post Item - Malformed JSON", func(t *testing.T) {
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "POST",
+			Resource:   "/todo-api",
+			Body:       `{"title": "List",`,
+		})
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
 	t.Run("Test Post Item - Error", func(t *testing.T) {
 		item := &model.Item{
 			Title: "List",
 			Text:  "Homework",
 		}
-		mockService.EXPECT().PostItem(gomock.Eq(item)).Return(errors.New("Error"))
+		mockService.EXPECT().PostItem(gomock.Any(), gomock.Eq(item)).Return(nil, errors.New("Error"))
 
 		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
 			HTTPMethod: "POST",
@@ -205,3 +558,385 @@ func TestPostHandler(t *testing.T) {
 	})
 
 }
+
+func TestPutHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mock_todo.NewMockService(ctrl)
+	handler := NewLambdaHandler(mockService)
+	handler.BuildRoutes()
+
+	t.Run("Test Put Item - OK", func(t *testing.T) {
+		item := &model.Item{
+			Title: "List",
+			Text:  "Homework",
+		}
+		mockService.EXPECT().UpdateItem(gomock.Any(), gomock.Eq(defaultID), gomock.Eq(int64(1)), gomock.Eq(item)).Return(&model.Item{}, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "PUT",
+			Resource:   "/todo-api/{id}",
+			Headers:    map[string]string{"If-Match": `"1"`},
+			PathParameters: map[string]string{
+				"id": defaultID,
+			},
+			Body: `{"title": "List", "text":"Homework"}`,
+		})
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("Test Put Item - BadRequest", func(t *testing.T) {
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "PUT",
+			Resource:   "/todo-api/{id}",
+			Headers:    map[string]string{"If-Match": `"1"`},
+			PathParameters: map[string]string{
+				"id": defaultID,
+			},
+			Body: `{"title": "", "text":""}`,
+		})
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("Test Put Item - Not found", func(t *testing.T) {
+		mockService.EXPECT().UpdateItem(gomock.Any(), gomock.Eq(defaultID), gomock.Eq(int64(1)), gomock.Any()).Return(nil, todo.ErrNotFound)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "PUT",
+			Resource:   "/todo-api/{id}",
+			Headers:    map[string]string{"If-Match": `"1"`},
+			PathParameters: map[string]string{
+				"id": defaultID,
+			},
+			Body: `{"title": "List", "text":"Homework"}`,
+		})
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	})
+
+	t.Run("Test Put Item - Error", func(t *testing.T) {
+		mockService.EXPECT().UpdateItem(gomock.Any(), gomock.Eq(defaultID), gomock.Eq(int64(1)), gomock.Any()).Return(nil, errors.New("Error"))
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "PUT",
+			Resource:   "/todo-api/{id}",
+			Headers:    map[string]string{"If-Match": `"1"`},
+			PathParameters: map[string]string{
+				"id": defaultID,
+			},
+			Body: `{"title": "List", "text":"Homework"}`,
+		})
+		assert.Equal(t, http.StatusInternalServerError, response.StatusCode)
+	})
+}
+
+func TestPatchHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mock_todo.NewMockService(ctrl)
+	handler := NewLambdaHandler(mockService)
+	handler.BuildRoutes()
+
+	t.Run("Test Patch Item - OK", func(t *testing.T) {
+		patch := map[string]interface{}{"title": "Groceries"}
+		mockService.EXPECT().PatchItem(gomock.Any(), gomock.Eq(defaultID), gomock.Eq(int64(1)), gomock.Eq(patch)).Return(&model.Item{}, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "PATCH",
+			Resource:   "/todo-api/{id}",
+			Headers: map[string]string{
+				"content-type": "application/merge-patch+json",
+				"if-match":     `"1"`,
+			},
+			PathParameters: map[string]string{
+				"id": defaultID,
+			},
+			Body: `{"title": "Groceries"}`,
+		})
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("Test Patch Item - BadRequest", func(t *testing.T) {
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "PATCH",
+			Resource:   "/todo-api/{id}",
+			Headers:    map[string]string{"If-Match": `"1"`},
+			PathParameters: map[string]string{
+				"id": defaultID,
+			},
+			Body: `["title"]`,
+		})
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("Test Patch Item - Unsupported media type", func(t *testing.T) {
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "PATCH",
+			Resource:   "/todo-api/{id}",
+			Headers: map[string]string{
+				"Content-Type": "text/plain",
+				"If-Match":     `"1"`,
+			},
+			PathParameters: map[string]string{
+				"id": defaultID,
+			},
+			Body: `{"title": "Groceries"}`,
+		})
+		assert.Equal(t, http.StatusUnsupportedMediaType, response.StatusCode)
+	})
+
+	t.Run("Test Patch Item - Validation error", func(t *testing.T) {
+		mockService.EXPECT().PatchItem(gomock.Any(), gomock.Eq(defaultID), gomock.Eq(int64(1)), gomock.Any()).Return(nil, todo.ErrValidation)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "PATCH",
+			Resource:   "/todo-api/{id}",
+			Headers:    map[string]string{"If-Match": `"1"`},
+			PathParameters: map[string]string{
+				"id": defaultID,
+			},
+			Body: `{"ID": "other"}`,
+		})
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("Test Patch Item - Not found", func(t *testing.T) {
+		mockService.EXPECT().PatchItem(gomock.Any(), gomock.Eq(defaultID), gomock.Eq(int64(1)), gomock.Any()).Return(nil, todo.ErrNotFound)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "PATCH",
+			Resource:   "/todo-api/{id}",
+			Headers:    map[string]string{"If-Match": `"1"`},
+			PathParameters: map[string]string{
+				"id": defaultID,
+			},
+			Body: `{"title": "Groceries"}`,
+		})
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	})
+}
+
+func TestTransitionHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mock_todo.NewMockService(ctrl)
+	handler := NewLambdaHandler(mockService)
+	handler.BuildRoutes()
+
+	t.Run("Test Complete Item - OK", func(t *testing.T) {
+		mockService.EXPECT().CompleteItem(gomock.Any(), gomock.Eq(defaultID)).Return(&model.Item{ID: defaultID, Done: true}, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "POST",
+			Resource:   "/todo-api/{id}/complete",
+			PathParameters: map[string]string{
+				"id": defaultID,
+			},
+		})
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("Test Complete Item - Conflict", func(t *testing.T) {
+		mockService.EXPECT().CompleteItem(gomock.Any(), gomock.Eq(defaultID)).Return(nil, todo.ErrConflict)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "POST",
+			Resource:   "/todo-api/{id}/complete",
+			PathParameters: map[string]string{
+				"id": defaultID,
+			},
+		})
+		assert.Equal(t, http.StatusConflict, response.StatusCode)
+	})
+
+	t.Run("Test Reopen Item - Not found", func(t *testing.T) {
+		mockService.EXPECT().ReopenItem(gomock.Any(), gomock.Eq(defaultID)).Return(nil, todo.ErrNotFound)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "POST",
+			Resource:   "/todo-api/{id}/reopen",
+			PathParameters: map[string]string{
+				"id": defaultID,
+			},
+		})
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	})
+}
+
+type recordedRequest struct {
+	route  string
+	status int
+}
+
+type fakeRecorder struct {
+	requests []recordedRequest
+}
+
+func (recorder *fakeRecorder) ObserveRequest(route string, status int, duration time.Duration) {
+	recorder.requests = append(recorder.requests, recordedRequest{route, status})
+}
+
+func TestHandleRequestMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mock_todo.NewMockService(ctrl)
+	recorder := &fakeRecorder{}
+	handler := NewLambdaHandler(mockService, WithMetrics(recorder))
+	handler.BuildRoutes()
+
+	mockService.EXPECT().GetItem(gomock.Any(), gomock.Eq(defaultID)).Return(nil, todo.ErrNotFound)
+
+	handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+		HTTPMethod:     "GET",
+		Resource:       "/todo-api/{id}",
+		PathParameters: map[string]string{"id": defaultID},
+	})
+	handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+		HTTPMethod: "OPTIONS",
+		Resource:   "/todo-api",
+	})
+
+	assert.Equal(t, []recordedRequest{
+		{"GET:/todo-api/{id}", http.StatusNotFound},
+		{"OPTIONS:/todo-api", http.StatusNotImplemented},
+	}, recorder.requests)
+}
+
+func TestConditionalWrites(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mock_todo.NewMockService(ctrl)
+	handler := NewLambdaHandler(mockService)
+	handler.BuildRoutes()
+
+	write := func(method string, ifMatch string) events.APIGatewayProxyResponse {
+		request := events.APIGatewayProxyRequest{
+			HTTPMethod:     method,
+			Resource:       "/todo-api/{id}",
+			PathParameters: map[string]string{"id": defaultID},
+			Body:           `{"title": "List", "text":"Homework"}`,
+		}
+		if ifMatch != "" {
+			request.Headers = map[string]string{"If-Match": ifMatch}
+		}
+		response, _ := handler.HandleRequest(context.TODO(), request)
+		return response
+	}
+
+	t.Run("Test Get Item exposes the ETag", func(t *testing.T) {
+		mockService.EXPECT().GetItem(gomock.Any(), gomock.Eq(defaultID)).Return(&model.Item{ID: defaultID, Version: 7}, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:     "GET",
+			Resource:       "/todo-api/{id}",
+			PathParameters: map[string]string{"id": defaultID},
+		})
+		assert.Equal(t, `"7"`, response.Headers["ETag"])
+	})
+
+	t.Run("Test writes require If-Match", func(t *testing.T) {
+		for _, method := range []string{"PUT", "PATCH", "DELETE"} {
+			assert.Equal(t, http.StatusPreconditionRequired, write(method, "").StatusCode, method)
+		}
+	})
+
+	t.Run("Test weak and malformed tags never match", func(t *testing.T) {
+		assert.Equal(t, http.StatusPreconditionFailed, write("PUT", `W/"1"`).StatusCode)
+		assert.Equal(t, http.StatusPreconditionFailed, write("PUT", `1`).StatusCode)
+	})
+
+	t.Run("Test stale version", func(t *testing.T) {
+		mockService.EXPECT().UpdateItem(gomock.Any(), gomock.Eq(defaultID), gomock.Eq(int64(1)), gomock.Any()).Return(nil, todo.ErrVersionMismatch)
+
+		assert.Equal(t, http.StatusPreconditionFailed, write("PUT", `"1"`).StatusCode)
+	})
+
+	t.Run("Test any version", func(t *testing.T) {
+		mockService.EXPECT().DeleteItem(gomock.Any(), gomock.Eq(defaultID), gomock.Eq(todo.AnyVersion)).Return(nil)
+
+		assert.Equal(t, http.StatusOK, write("DELETE", "*").StatusCode)
+	})
+
+	t.Run("Test update returns the new ETag", func(t *testing.T) {
+		mockService.EXPECT().PatchItem(gomock.Any(), gomock.Eq(defaultID), gomock.Eq(int64(2)), gomock.Any()).Return(&model.Item{ID: defaultID, Version: 3}, nil)
+
+		response := write("PATCH", `"2"`)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, `"3"`, response.Headers["ETag"])
+	})
+}
+
+func TestConditionalGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mock_todo.NewMockService(ctrl)
+	handler := NewLambdaHandler(mockService)
+	handler.BuildRoutes()
+	updatedAt := time.Date(2021, 3, 20, 10, 0, 0, 500, time.UTC)
+	item := &model.Item{ID: defaultID, Title: "List", Text: "Homework", Version: 2, UpdatedAt: &updatedAt}
+
+	getItem := func(headers map[string]string) events.APIGatewayProxyResponse {
+		mockService.EXPECT().GetItem(gomock.Any(), gomock.Eq(defaultID)).Return(item, nil)
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:     "GET",
+			Resource:       "/todo-api/{id}",
+			Headers:        headers,
+			PathParameters: map[string]string{"id": defaultID},
+		})
+		return response
+	}
+
+	t.Run("Test Get Item sends validators", func(t *testing.T) {
+		response := getItem(nil)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, `"2"`, response.Headers["ETag"])
+		assert.Equal(t, "Sat, 20 Mar 2021 10:00:00 GMT", response.Headers["Last-Modified"])
+	})
+
+	t.Run("Test Get Item - If-None-Match", func(t *testing.T) {
+		response := getItem(map[string]string{"If-None-Match": `"1", W/"2"`})
+		assert.Equal(t, http.StatusNotModified, response.StatusCode)
+		assert.Empty(t, response.Body)
+		assert.Equal(t, `"2"`, response.Headers["ETag"])
+
+		assert.Equal(t, http.StatusOK, getItem(map[string]string{"If-None-Match": `"1"`}).StatusCode)
+	})
+
+	t.Run("Test Get Item - If-Modified-Since", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotModified, getItem(map[string]string{"If-Modified-Since": "Sat, 20 Mar 2021 10:00:00 GMT"}).StatusCode)
+		assert.Equal(t, http.StatusOK, getItem(map[string]string{"If-Modified-Since": "Sat, 20 Mar 2021 09:59:59 GMT"}).StatusCode)
+	})
+
+	t.Run("Test Get Item - If-None-Match wins over If-Modified-Since", func(t *testing.T) {
+		response := getItem(map[string]string{
+			"If-None-Match":     `"1"`,
+			"If-Modified-Since": "Sat, 20 Mar 2021 10:00:00 GMT",
+		})
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("Test Get Items - If-None-Match", func(t *testing.T) {
+		page := &model.Page{Items: []*model.Item{item}}
+		mockService.EXPECT().GetItems(gomock.Any(), gomock.Any()).Return(page, nil).Times(3)
+		list := func(headers map[string]string) events.APIGatewayProxyResponse {
+			response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+				HTTPMethod: "GET",
+				Resource:   "/todo-api",
+				Headers:    headers,
+			})
+			return response
+		}
+
+		first := list(nil)
+		assert.Equal(t, http.StatusOK, first.StatusCode)
+		assert.NotEmpty(t, first.Headers["ETag"])
+
+		assert.Equal(t, http.StatusNotModified, list(map[string]string{"If-None-Match": first.Headers["ETag"]}).StatusCode)
+		assert.Equal(t, http.StatusOK, list(map[string]string{"If-Modified-Since": "Sat, 20 Mar 2021 10:00:00 GMT"}).StatusCode)
+	})
+}
@@ -0,0 +1,180 @@
+package function
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+	"github.com/BrunoDM2943/go-todo-lambda/internal/module/lists"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// WithListService serves the list routes, which are left out without it.
+func WithListService(listService lists.Service) Option {
+	return func(handler *LambdaHandler) {
+		handler.listService = listService
+	}
+}
+
+func (handler *LambdaHandler) buildListRoutes() {
+	for key, route := range map[string]handleFunc{
+		"GET:/todo-api/lists":                 handler.getLists,
+		"POST:/todo-api/lists":                handler.postListHandler,
+		"GET:/todo-api/lists/{listId}":        handler.getList,
+		"PUT:/todo-api/lists/{listId}":        handler.putListHandler,
+		"DELETE:/todo-api/lists/{listId}":     handler.deleteListHandler,
+		"GET:/todo-api/lists/{listId}/items":  handler.getListItems,
+		"POST:/todo-api/lists/{listId}/items": handler.postListItemHandler,
+		"POST:/todo-api/{id}/move":            handler.moveHandler,
+	} {
+		handler.routes[key] = route
+	}
+}
+
+func (handler *LambdaHandler) getLists(ctx context.Context, request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	all, err := handler.listService.GetLists(ctx)
+	if err != nil {
+		return handler.buildErrorResponse(request, err)
+	}
+	body, _ := json.Marshal(map[string]interface{}{"lists": all})
+	return buildSuccessResponse(string(body))
+}
+
+func (handler *LambdaHandler) postListHandler(ctx context.Context, request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	list := &model.List{}
+	if err := json.Unmarshal([]byte(request.Body), list); err != nil || list.Name == "" {
+		return buildProblemResponse(request, http.StatusBadRequest, "Invalid body, expected the list name")
+	}
+	created, err := handler.listService.PostList(ctx, list)
+	if err != nil {
+		return handler.buildErrorResponse(request, err)
+	}
+	response := buildListResponse(created)
+	response.StatusCode = http.StatusCreated
+	response.Headers["Location"] = fmt.Sprintf("/todo-api/lists/%s", created.ID)
+	return response
+}
+
+func (handler *LambdaHandler) getList(ctx context.Context, request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	list, err := handler.listService.GetList(ctx, request.PathParameters["listId"])
+	if err != nil {
+		return handler.buildErrorResponse(request, err)
+	}
+	if etag := formatETag(list.Version); notModified(request, etag, list.UpdatedAt) {
+		return buildNotModifiedResponse(etag, list.UpdatedAt)
+	}
+	return buildListResponse(list)
+}
+
+func (handler *LambdaHandler) putListHandler(ctx context.Context, request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	id := request.PathParameters["listId"]
+	if id == "" {
+		return buildProblemResponse(request, http.StatusBadRequest, "Invalid list ID")
+	}
+	version, failed := expectedVersion(request)
+	if failed != nil {
+		return *failed
+	}
+	list := &model.List{}
+	if err := json.Unmarshal([]byte(request.Body), list); err != nil || list.Name == "" {
+		return buildProblemResponse(request, http.StatusBadRequest, "Invalid body, expected the list name")
+	}
+	updated, err := handler.listService.UpdateList(ctx, id, version, list)
+	if err != nil {
+		return handler.buildErrorResponse(request, err)
+	}
+	return buildListResponse(updated)
+}
+
+func (handler *LambdaHandler) deleteListHandler(ctx context.Context, request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	id := request.PathParameters["listId"]
+	if id == "" {
+		return buildProblemResponse(request, http.StatusBadRequest, "Invalid list ID")
+	}
+	version, failed := expectedVersion(request)
+	if failed != nil {
+		return *failed
+	}
+	cascade := lists.Cascade(request.QueryStringParameters["cascade"])
+	if err := handler.listService.DeleteList(ctx, id, version, cascade); err != nil {
+		return handler.buildErrorResponse(request, err)
+	}
+	return buildEmptyResponse(http.StatusOK)
+}
+
+func (handler *LambdaHandler) getListItems(ctx context.Context, request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	query, invalid := parseItemQuery(request)
+	if invalid != "" {
+		return buildProblemResponse(request, http.StatusBadRequest, invalid)
+	}
+	page, err := handler.listService.GetListItems(ctx, request.PathParameters["listId"], query)
+	if err != nil {
+		return handler.buildErrorResponse(request, err)
+	}
+	return buildPageResponse(request, page)
+}
+
+func (handler *LambdaHandler) postListItemHandler(ctx context.Context, request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	id := request.PathParameters["listId"]
+	if id == "" {
+		return buildProblemResponse(request, http.StatusBadRequest, "Invalid list ID")
+	}
+	item, invalid := decodeItem(request.Body)
+	if invalid != "" {
+		return buildProblemResponse(request, http.StatusBadRequest, invalid)
+	}
+	created, err := handler.listService.AddItem(ctx, id, item)
+	if err != nil {
+		return handler.buildErrorResponse(request, err)
+	}
+	return buildCreatedResponse(fmt.Sprintf("/todo-api/%s", created.ID), created)
+}
+
+// moveHandler takes {"listId": "..."}, or {"listId": null} to take the item
+// out of its list; a body without listId is rejected rather than read as
+// null. Like the other sub-routes of an item it takes no If-Match, see
+// transitionItem.
+func (handler *LambdaHandler) moveHandler(ctx context.Context, request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	id := request.PathParameters["id"]
+	if id == "" {
+		return buildProblemResponse(request, http.StatusBadRequest, "Invalid ID")
+	}
+	listID, ok := parseMoveTarget(request.Body)
+	if !ok {
+		return buildProblemResponse(request, http.StatusBadRequest, "Invalid body, expected the list to move the item to")
+	}
+	item, err := handler.listService.MoveItem(ctx, id, listID)
+	if err != nil {
+		return handler.buildErrorResponse(request, err)
+	}
+	return buildItemResponse(item)
+}
+
+// parseMoveTarget returns an empty listID for a null listId.
+func parseMoveTarget(body string) (string, bool) {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal([]byte(body), &fields); err != nil {
+		return "", false
+	}
+	raw, ok := fields["listId"]
+	if !ok {
+		return "", false
+	}
+	var listID *string
+	if err := json.Unmarshal(raw, &listID); err != nil {
+		return "", false
+	}
+	if listID == nil {
+		return "", true
+	}
+	return *listID, *listID != ""
+}
+
+func buildListResponse(list *model.List) events.APIGatewayProxyResponse {
+	body, _ := json.Marshal(list)
+	response := buildSuccessResponse(string(body))
+	setValidators(response.Headers, formatETag(list.Version), list.UpdatedAt)
+	return response
+}
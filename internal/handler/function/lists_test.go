@@ -0,0 +1,205 @@
+package function
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+	"github.com/BrunoDM2943/go-todo-lambda/internal/module/lists"
+	mock_lists "github.com/BrunoDM2943/go-todo-lambda/internal/module/lists/mock"
+	"github.com/BrunoDM2943/go-todo-lambda/internal/module/todo"
+	mock_todo "github.com/BrunoDM2943/go-todo-lambda/internal/module/todo/mock"
+)
+
+func TestListHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mock_todo.NewMockService(ctrl)
+	mockLists := mock_lists.NewMockService(ctrl)
+	handler := NewLambdaHandler(mockService, WithListService(mockLists))
+	handler.BuildRoutes()
+
+	t.Run("Test routes need the list service", func(t *testing.T) {
+		plain := NewLambdaHandler(mockService)
+		plain.BuildRoutes()
+		response, _ := plain.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "GET",
+			Resource:   "/todo-api/lists",
+		})
+		assert.Equal(t, http.StatusNotImplemented, response.StatusCode)
+	})
+
+	t.Run("Test Get Lists - OK", func(t *testing.T) {
+		mockLists.EXPECT().GetLists(gomock.Any()).Return([]*model.List{{ID: "work", Name: "Work", Version: 1}}, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "GET",
+			Resource:   "/todo-api/lists",
+		})
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.JSONEq(t, `{"lists":[{"ID":"work","name":"Work","version":1}]}`, response.Body)
+	})
+
+	t.Run("Test Post List - OK", func(t *testing.T) {
+		mockLists.EXPECT().PostList(gomock.Any(), &model.List{Name: "Work"}).Return(&model.List{ID: "work", Name: "Work", Version: 1}, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "POST",
+			Resource:   "/todo-api/lists",
+			Body:       `{"name":"Work"}`,
+		})
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+		assert.Equal(t, "/todo-api/lists/work", response.Headers["Location"])
+		assert.Equal(t, `"1"`, response.Headers["ETag"])
+	})
+
+	t.Run("Test Post List - Missing name", func(t *testing.T) {
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "POST",
+			Resource:   "/todo-api/lists",
+			Body:       `{}`,
+		})
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("Test Get List - Not found", func(t *testing.T) {
+		mockLists.EXPECT().GetList(gomock.Any(), "gone").Return(nil, todo.ErrNotFound)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:     "GET",
+			Resource:       "/todo-api/lists/{listId}",
+			PathParameters: map[string]string{"listId": "gone"},
+		})
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	})
+
+	t.Run("Test Put List - Needs If-Match", func(t *testing.T) {
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:     "PUT",
+			Resource:       "/todo-api/lists/{listId}",
+			PathParameters: map[string]string{"listId": "work"},
+			Body:           `{"name":"Office"}`,
+		})
+		assert.Equal(t, http.StatusPreconditionRequired, response.StatusCode)
+	})
+
+	t.Run("Test Put List - OK", func(t *testing.T) {
+		mockLists.EXPECT().UpdateList(gomock.Any(), "work", int64(1), &model.List{Name: "Office"}).Return(&model.List{ID: "work", Name: "Office", Version: 2}, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:     "PUT",
+			Resource:       "/todo-api/lists/{listId}",
+			PathParameters: map[string]string{"listId": "work"},
+			Headers:        map[string]string{"If-Match": `"1"`},
+			Body:           `{"name":"Office"}`,
+		})
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, `"2"`, response.Headers["ETag"])
+	})
+
+	t.Run("Test Delete List - Cascade", func(t *testing.T) {
+		mockLists.EXPECT().DeleteList(gomock.Any(), "work", todo.AnyVersion, lists.CascadeArchive).Return(nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:            "DELETE",
+			Resource:              "/todo-api/lists/{listId}",
+			PathParameters:        map[string]string{"listId": "work"},
+			QueryStringParameters: map[string]string{"cascade": "archive"},
+			Headers:               map[string]string{"If-Match": "*"},
+		})
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("Test Delete List - Not empty", func(t *testing.T) {
+		mockLists.EXPECT().DeleteList(gomock.Any(), "work", int64(1), lists.Cascade("")).Return(todo.ErrConflict)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:     "DELETE",
+			Resource:       "/todo-api/lists/{listId}",
+			PathParameters: map[string]string{"listId": "work"},
+			Headers:        map[string]string{"If-Match": `"1"`},
+		})
+		assert.Equal(t, http.StatusConflict, response.StatusCode)
+	})
+
+	t.Run("Test Get List Items - OK", func(t *testing.T) {
+		mockLists.EXPECT().GetListItems(gomock.Any(), "work", model.ListQuery{
+			Limit:  5,
+			Filter: model.ItemFilter{Status: model.StatusOpen, Archived: &unarchived},
+		}).Return(&model.Page{}, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:            "GET",
+			Resource:              "/todo-api/lists/{listId}/items",
+			PathParameters:        map[string]string{"listId": "work"},
+			QueryStringParameters: map[string]string{"limit": "5", "status": "open"},
+		})
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("Test Post List Item - OK", func(t *testing.T) {
+		mockLists.EXPECT().AddItem(gomock.Any(), "work", &model.Item{Title: "Report", Text: "text"}).Return(&model.Item{ID: defaultID, ListID: "work"}, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:     "POST",
+			Resource:       "/todo-api/lists/{listId}/items",
+			PathParameters: map[string]string{"listId": "work"},
+			Body:           `{"title":"Report","text":"text"}`,
+		})
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+		assert.Equal(t, "/todo-api/"+defaultID, response.Headers["Location"])
+	})
+
+	t.Run("Test Post Item - Ignores listId", func(t *testing.T) {
+		mockService.EXPECT().PostItem(gomock.Any(), &model.Item{Title: "Report", Text: "text"}).Return(&model.Item{ID: defaultID}, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "POST",
+			Resource:   "/todo-api",
+			Body:       `{"title":"Report","text":"text","listId":"work"}`,
+		})
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+	})
+
+	t.Run("Test Move Item - OK", func(t *testing.T) {
+		mockLists.EXPECT().MoveItem(gomock.Any(), defaultID, "home").Return(&model.Item{ID: defaultID, ListID: "home"}, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:     "POST",
+			Resource:       "/todo-api/{id}/move",
+			PathParameters: map[string]string{"id": defaultID},
+			Body:           `{"listId":"home"}`,
+		})
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("Test Move Item - Out of its list", func(t *testing.T) {
+		mockLists.EXPECT().MoveItem(gomock.Any(), defaultID, "").Return(&model.Item{ID: defaultID}, nil)
+
+		response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:     "POST",
+			Resource:       "/todo-api/{id}/move",
+			PathParameters: map[string]string{"id": defaultID},
+			Body:           `{"listId":null}`,
+		})
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("Test Move Item - Invalid body", func(t *testing.T) {
+		for _, body := range []string{`{}`, `{"listID":"home"}`, `{"listId":""}`, `{"listId":1}`, `null`} {
+			response, _ := handler.HandleRequest(context.TODO(), events.APIGatewayProxyRequest{
+				HTTPMethod:     "POST",
+				Resource:       "/todo-api/{id}/move",
+				PathParameters: map[string]string{"id": defaultID},
+				Body:           body,
+			})
+			assert.Equal(t, http.StatusBadRequest, response.StatusCode, body)
+		}
+	})
+}
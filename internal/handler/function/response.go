@@ -0,0 +1,143 @@
+package function
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+	"github.com/BrunoDM2943/go-todo-lambda/internal/module/todo"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+const (
+	contentTypeJSON    = "application/json"
+	contentTypeProblem = "application/problem+json"
+	problemTypePrefix  = "urn:todo-api:problem:"
+)
+
+// problem is an RFC 7807 error document.
+type problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, todo.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, todo.ErrValidation):
+		return http.StatusBadRequest
+	case errors.Is(err, todo.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, todo.ErrVersionMismatch):
+		return http.StatusPreconditionFailed
+	}
+	return http.StatusInternalServerError
+}
+
+func (handler *LambdaHandler) buildErrorResponse(request events.APIGatewayProxyRequest, err error) events.APIGatewayProxyResponse {
+	status := statusForError(err)
+	if status == http.StatusInternalServerError {
+		handler.logger.Errorf("Request %s failed: %v", request.RequestContext.RequestID, err)
+		return buildProblemResponse(request, status, "The request could not be processed")
+	}
+	return buildProblemResponse(request, status, err.Error())
+}
+
+func buildProblemResponse(request events.APIGatewayProxyRequest, status int, detail string) events.APIGatewayProxyResponse {
+	title := http.StatusText(status)
+	body, _ := json.Marshal(problem{
+		Type:     problemTypePrefix + strings.ToLower(strings.ReplaceAll(title, " ", "-")),
+		Title:    title,
+		Status:   status,
+		Detail:   detail,
+		Instance: request.RequestContext.RequestID,
+	})
+	return buildResponse(status, contentTypeProblem, string(body))
+}
+
+func buildSuccessResponse(body string) events.APIGatewayProxyResponse {
+	return buildResponse(http.StatusOK, contentTypeJSON, body)
+}
+
+// itemView is an item as the API renders it, with the progress of its
+// checklist rolled up.
+type itemView struct {
+	*model.Item
+	Progress *model.Progress `json:"progress,omitempty"`
+}
+
+func newItemView(item *model.Item) itemView {
+	return itemView{Item: item, Progress: model.ChecklistProgress(item.Checklist)}
+}
+
+type pageView struct {
+	Items      []itemView `json:"items"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+}
+
+func newPageView(page *model.Page) pageView {
+	view := pageView{NextCursor: page.NextCursor}
+	if page.Items != nil {
+		view.Items = make([]itemView, 0, len(page.Items))
+		for _, item := range page.Items {
+			view.Items = append(view.Items, newItemView(item))
+		}
+	}
+	return view
+}
+
+// buildItemResponse renders a single item along with its validators.
+func buildItemResponse(item *model.Item) events.APIGatewayProxyResponse {
+	body, _ := json.Marshal(newItemView(item))
+	response := buildSuccessResponse(string(body))
+	setValidators(response.Headers, formatETag(item.Version), item.UpdatedAt)
+	return response
+}
+
+func buildNotModifiedResponse(etag string, lastModified *time.Time) events.APIGatewayProxyResponse {
+	response := events.APIGatewayProxyResponse{
+		StatusCode: http.StatusNotModified,
+		Headers:    map[string]string{},
+	}
+	setValidators(response.Headers, etag, lastModified)
+	return response
+}
+
+func setValidators(headers map[string]string, etag string, lastModified *time.Time) {
+	headers["ETag"] = etag
+	if lastModified != nil {
+		headers["Last-Modified"] = lastModified.UTC().Format(http.TimeFormat)
+	}
+}
+
+func buildCreatedResponse(location string, item *model.Item) events.APIGatewayProxyResponse {
+	response := buildItemResponse(item)
+	response.StatusCode = http.StatusCreated
+	response.Headers["Location"] = location
+	return response
+}
+
+// buildEmptyResponse has no body, so it claims no content type either.
+func buildEmptyResponse(status int) events.APIGatewayProxyResponse {
+	return events.APIGatewayProxyResponse{
+		StatusCode: status,
+		Headers:    map[string]string{},
+	}
+}
+
+func buildResponse(status int, contentType string, body string) events.APIGatewayProxyResponse {
+	return events.APIGatewayProxyResponse{
+		StatusCode: status,
+		Headers: map[string]string{
+			"Content-Type": contentType,
+		},
+		Body: body,
+	}
+}
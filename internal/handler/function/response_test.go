@@ -0,0 +1,69 @@
+package function
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/logging"
+	"github.com/BrunoDM2943/go-todo-lambda/internal/module/todo"
+)
+
+func TestBuildErrorResponse(t *testing.T) {
+	handler := NewLambdaHandler(nil, WithLogger(logging.New(ioutil.Discard, logging.LevelInfo)))
+	request := events.APIGatewayProxyRequest{
+		RequestContext: events.APIGatewayProxyRequestContext{
+			RequestID: "request-id",
+		},
+	}
+
+	t.Run("Escapes the detail", func(t *testing.T) {
+		err := fmt.Errorf("%w: title \"x\"\nis invalid", todo.ErrValidation)
+		response := handler.buildErrorResponse(request, err)
+
+		document := problem{}
+		assert.Nil(t, json.Unmarshal([]byte(response.Body), &document))
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+		assert.Equal(t, contentTypeProblem, response.Headers["Content-Type"])
+		assert.Equal(t, problem{
+			Type:     "urn:todo-api:problem:bad-request",
+			Title:    "Bad Request",
+			Status:   http.StatusBadRequest,
+			Detail:   err.Error(),
+			Instance: "request-id",
+		}, document)
+	})
+
+	t.Run("Maps typed errors", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, handler.buildErrorResponse(request, todo.ErrNotFound).StatusCode)
+		assert.Equal(t, http.StatusConflict, handler.buildErrorResponse(request, todo.ErrConflict).StatusCode)
+	})
+
+	t.Run("Hides internal errors", func(t *testing.T) {
+		response := handler.buildErrorResponse(request, errors.New("dynamodb: connection reset"))
+
+		document := problem{}
+		assert.Nil(t, json.Unmarshal([]byte(response.Body), &document))
+		assert.Equal(t, http.StatusInternalServerError, document.Status)
+		assert.NotContains(t, document.Detail, "dynamodb")
+	})
+}
+
+func TestBuildSuccessResponse(t *testing.T) {
+	response := buildSuccessResponse(`{}`)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, contentTypeJSON, response.Headers["Content-Type"])
+}
+
+func TestBuildEmptyResponse(t *testing.T) {
+	response := buildEmptyResponse(http.StatusNoContent)
+	assert.Equal(t, http.StatusNoContent, response.StatusCode)
+	assert.Empty(t, response.Body)
+	assert.NotContains(t, response.Headers, "Content-Type")
+}
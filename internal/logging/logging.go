@@ -0,0 +1,72 @@
+// Package logging is a small levelled wrapper around the standard logger.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"strings"
+)
+
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+var levelNames = []string{"DEBUG", "INFO", "WARN", "ERROR"}
+
+func (level Level) String() string {
+	if level < LevelDebug || level > LevelError {
+		return fmt.Sprintf("Level(%d)", int(level))
+	}
+	return levelNames[level]
+}
+
+// ParseLevel accepts the level names in any case.
+func ParseLevel(name string) (Level, error) {
+	for level, candidate := range levelNames {
+		if strings.EqualFold(name, candidate) {
+			return Level(level), nil
+		}
+	}
+	return LevelInfo, fmt.Errorf("unknown log level %q", name)
+}
+
+type Logger struct {
+	level Level
+	out   *log.Logger
+}
+
+func New(out io.Writer, level Level) *Logger {
+	return &Logger{
+		level: level,
+		out:   log.New(out, "", log.LstdFlags),
+	}
+}
+
+func (logger *Logger) Debugf(format string, args ...interface{}) {
+	logger.logf(LevelDebug, format, args...)
+}
+
+func (logger *Logger) Infof(format string, args ...interface{}) {
+	logger.logf(LevelInfo, format, args...)
+}
+
+func (logger *Logger) Warnf(format string, args ...interface{}) {
+	logger.logf(LevelWarn, format, args...)
+}
+
+func (logger *Logger) Errorf(format string, args ...interface{}) {
+	logger.logf(LevelError, format, args...)
+}
+
+func (logger *Logger) logf(level Level, format string, args ...interface{}) {
+	if level < logger.level {
+		return
+	}
+	logger.out.Printf("%s %s", level, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,32 @@
+package logging
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLevel(t *testing.T) {
+	level, err := ParseLevel("warn")
+	assert.Nil(t, err)
+	assert.Equal(t, LevelWarn, level)
+
+	_, err = ParseLevel("verbose")
+	assert.NotNil(t, err)
+}
+
+func TestLogger(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger := New(out, LevelWarn)
+
+	logger.Debugf("debug %d", 1)
+	logger.Infof("info %d", 2)
+	logger.Warnf("warn %d", 3)
+	logger.Errorf("error %d", 4)
+
+	assert.NotContains(t, out.String(), "debug 1")
+	assert.NotContains(t, out.String(), "info 2")
+	assert.Contains(t, out.String(), "WARN warn 3")
+	assert.Contains(t, out.String(), "ERROR error 4")
+}
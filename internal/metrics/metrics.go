@@ -0,0 +1,73 @@
+// Package metrics records request metrics. On Lambda they are written in the
+// CloudWatch embedded metric format, so no extra API calls are needed.
+package metrics
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Recorder receives one observation per handled request.
+type Recorder interface {
+	ObserveRequest(route string, status int, duration time.Duration)
+}
+
+type nopRecorder struct{}
+
+// Nop discards every observation.
+func Nop() Recorder {
+	return nopRecorder{}
+}
+
+func (nopRecorder) ObserveRequest(string, int, time.Duration) {}
+
+type emfRecorder struct {
+	mutex     sync.Mutex
+	encoder   *json.Encoder
+	namespace string
+	now       func() time.Time
+}
+
+// NewEMF writes one embedded metric format document per request to out,
+// usually stdout, where CloudWatch Logs extracts it.
+func NewEMF(out io.Writer, namespace string) Recorder {
+	return &emfRecorder{
+		encoder:   json.NewEncoder(out),
+		namespace: namespace,
+		now:       time.Now,
+	}
+}
+
+func (recorder *emfRecorder) ObserveRequest(route string, status int, duration time.Duration) {
+	clientErrors, serverErrors := 0, 0
+	if status >= 500 {
+		serverErrors = 1
+	} else if status >= 400 {
+		clientErrors = 1
+	}
+	document := map[string]interface{}{
+		"_aws": map[string]interface{}{
+			"Timestamp": recorder.now().UnixNano() / int64(time.Millisecond),
+			"CloudWatchMetrics": []map[string]interface{}{{
+				"Namespace":  recorder.namespace,
+				"Dimensions": [][]string{{"Route"}},
+				"Metrics": []map[string]string{
+					{"Name": "Latency", "Unit": "Milliseconds"},
+					{"Name": "ClientErrors", "Unit": "Count"},
+					{"Name": "ServerErrors", "Unit": "Count"},
+				},
+			}},
+		},
+		"Route":        route,
+		"StatusCode":   status,
+		"Latency":      float64(duration) / float64(time.Millisecond),
+		"ClientErrors": clientErrors,
+		"ServerErrors": serverErrors,
+	}
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	_ = recorder.encoder.Encode(document)
+}
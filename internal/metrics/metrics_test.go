@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEMFRecorder(t *testing.T) {
+	out := &bytes.Buffer{}
+	recorder := NewEMF(out, "TodoAPI").(*emfRecorder)
+	recorder.now = func() time.Time { return time.Unix(1616000000, 0) }
+
+	recorder.ObserveRequest("GET:/todo-api/{id}", 404, 1500*time.Microsecond)
+
+	document := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal(out.Bytes(), &document))
+	assert.Equal(t, "GET:/todo-api/{id}", document["Route"])
+	assert.Equal(t, 1.5, document["Latency"])
+	assert.Equal(t, 1.0, document["ClientErrors"])
+	assert.Equal(t, 0.0, document["ServerErrors"])
+
+	metadata := document["_aws"].(map[string]interface{})
+	assert.Equal(t, 1616000000000.0, metadata["Timestamp"])
+	directive := metadata["CloudWatchMetrics"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "TodoAPI", directive["Namespace"])
+}
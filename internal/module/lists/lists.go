@@ -0,0 +1,237 @@
+package lists
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+	"github.com/BrunoDM2943/go-todo-lambda/internal/module/todo"
+	"github.com/BrunoDM2943/go-todo-lambda/internal/repository"
+)
+
+const MaxNameLength = 100
+
+// Cascade says what deleting a list does to the items still in it.
+type Cascade string
+
+const (
+	// CascadeReject refuses to delete a list that still has items.
+	CascadeReject Cascade = "reject"
+	// CascadeArchive takes the items out of the list and archives them.
+	CascadeArchive Cascade = "archive"
+	// CascadeDelete deletes the items with the list.
+	CascadeDelete Cascade = "delete"
+)
+
+//go:generate mockgen -source=./lists.go -destination=./mock/lists_mock.go
+type Service interface {
+	PostList(ctx context.Context, list *model.List) (*model.List, error)
+	GetList(ctx context.Context, id string) (*model.List, error)
+	GetLists(ctx context.Context) ([]*model.List, error)
+	UpdateList(ctx context.Context, id string, version int64, list *model.List) (*model.List, error)
+	DeleteList(ctx context.Context, id string, version int64, cascade Cascade) error
+	GetListItems(ctx context.Context, id string, query model.ListQuery) (*model.Page, error)
+	AddItem(ctx context.Context, id string, item *model.Item) (*model.Item, error)
+	MoveItem(ctx context.Context, itemID string, listID string) (*model.Item, error)
+}
+
+type listService struct {
+	lists       repository.ListRepository
+	items       repository.TodoRepository
+	todoService todo.Service
+	clock       todo.Clock
+}
+
+type Option func(*listService)
+
+func WithClock(clock todo.Clock) Option {
+	return func(service *listService) {
+		service.clock = clock
+	}
+}
+
+func NewListService(lists repository.ListRepository, items repository.TodoRepository, todoService todo.Service, options ...Option) Service {
+	service := &listService{
+		lists:       lists,
+		items:       items,
+		todoService: todoService,
+		clock:       todo.SystemClock{},
+	}
+	for _, option := range options {
+		option(service)
+	}
+	return service
+}
+
+func (service *listService) PostList(ctx context.Context, list *model.List) (*model.List, error) {
+	name, err := normalizeName(list.Name)
+	if err != nil {
+		return nil, err
+	}
+	now := service.now()
+	return service.lists.SaveList(ctx, &model.List{
+		Name:      name,
+		CreatedAt: &now,
+		UpdatedAt: &now,
+	})
+}
+
+func (service *listService) GetList(ctx context.Context, id string) (*model.List, error) {
+	return service.lists.FindListByID(ctx, id)
+}
+
+func (service *listService) GetLists(ctx context.Context) ([]*model.List, error) {
+	return service.lists.AllLists(ctx)
+}
+
+func (service *listService) UpdateList(ctx context.Context, id string, version int64, list *model.List) (*model.List, error) {
+	name, err := normalizeName(list.Name)
+	if err != nil {
+		return nil, err
+	}
+	current, err := service.findAtVersion(ctx, id, version)
+	if err != nil {
+		return nil, err
+	}
+	now := service.now()
+	updated := *current
+	updated.Name = name
+	updated.UpdatedAt = &now
+	return service.lists.UpdateList(ctx, &updated)
+}
+
+// DeleteList applies the cascade to the items of the list before deleting
+// the list itself, so a failure part way leaves the list in place and the
+// delete can be retried.
+func (service *listService) DeleteList(ctx context.Context, id string, version int64, cascade Cascade) error {
+	if cascade == "" {
+		cascade = CascadeReject
+	}
+	switch cascade {
+	case CascadeReject, CascadeArchive, CascadeDelete:
+	default:
+		return fmt.Errorf("%w: unknown cascade %s, expected reject, archive or delete", todo.ErrValidation, cascade)
+	}
+	current, err := service.findAtVersion(ctx, id, version)
+	if err != nil {
+		return err
+	}
+	items, err := todo.CollectItems(ctx, service.items, model.ItemFilter{ListID: id})
+	if err != nil {
+		return err
+	}
+	if len(items) > 0 {
+		if cascade == CascadeReject {
+			return fmt.Errorf("%w: list %s still has %d items", todo.ErrConflict, id, len(items))
+		}
+		for _, item := range items {
+			if err := service.releaseItem(ctx, item, id, cascade); err != nil {
+				return err
+			}
+		}
+	}
+	return service.lists.DeleteListByID(ctx, id, current.Version)
+}
+
+func (service *listService) GetListItems(ctx context.Context, id string, query model.ListQuery) (*model.Page, error) {
+	if _, err := service.lists.FindListByID(ctx, id); err != nil {
+		return nil, err
+	}
+	query.Filter.ListID = id
+	return service.todoService.GetItems(ctx, query)
+}
+
+func (service *listService) AddItem(ctx context.Context, id string, item *model.Item) (*model.Item, error) {
+	if _, err := service.lists.FindListByID(ctx, id); err != nil {
+		return nil, err
+	}
+	added := *item
+	added.ListID = id
+	added.Archived = false
+	return service.todoService.PostItem(ctx, &added)
+}
+
+// MoveItem puts an item in another list, or in none when listID is empty.
+// An archived item moved into a list is unarchived, the way releaseItem
+// archives the items it takes out of a deleted list.
+func (service *listService) MoveItem(ctx context.Context, itemID string, listID string) (*model.Item, error) {
+	if listID != "" {
+		if _, err := service.lists.FindListByID(ctx, listID); err != nil {
+			return nil, err
+		}
+	}
+	current, err := service.items.FindByID(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if current.ListID == listID {
+		return current, nil
+	}
+	var target interface{}
+	if listID != "" {
+		target = listID
+	}
+	values := map[string]interface{}{
+		"listId":    target,
+		"updatedAt": service.now(),
+	}
+	if listID != "" && current.Archived {
+		values["archived"] = false
+	}
+	item, err := service.items.Patch(ctx, itemID, current.Version, values)
+	if errors.Is(err, todo.ErrVersionMismatch) {
+		return nil, fmt.Errorf("%w: item %s changed concurrently", todo.ErrConflict, itemID)
+	}
+	return item, err
+}
+
+// releaseItem archives or deletes one item of a list being deleted. Items
+// moved to another list in the meantime are left alone. Deletes go through
+// the todo service, which unblocks the items waiting on the deleted ones.
+func (service *listService) releaseItem(ctx context.Context, item *model.Item, listID string, cascade Cascade) error {
+	_, err := todo.RetryWrite(ctx, service.items, item, func(item *model.Item) (bool, error) {
+		if item.ListID != listID {
+			return false, nil
+		}
+		if cascade == CascadeDelete {
+			return true, service.todoService.DeleteItem(ctx, item.ID, item.Version)
+		}
+		_, err := service.items.Patch(ctx, item.ID, item.Version, map[string]interface{}{
+			"listId":    nil,
+			"archived":  true,
+			"updatedAt": service.now(),
+		})
+		return true, err
+	})
+	return err
+}
+
+func (service *listService) findAtVersion(ctx context.Context, id string, version int64) (*model.List, error) {
+	current, err := service.lists.FindListByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if version != todo.AnyVersion && version != current.Version {
+		return nil, fmt.Errorf("%w: list %s is at version %d", todo.ErrVersionMismatch, id, current.Version)
+	}
+	return current, nil
+}
+
+// now is kept to millisecond precision, like the todo service.
+func (service *listService) now() time.Time {
+	return service.clock.Now().UTC().Truncate(time.Millisecond)
+}
+
+func normalizeName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("%w: list name is required", todo.ErrValidation)
+	}
+	if len([]rune(name)) > MaxNameLength {
+		return "", fmt.Errorf("%w: list name is longer than %d characters", todo.ErrValidation, MaxNameLength)
+	}
+	return name, nil
+}
@@ -0,0 +1,244 @@
+package lists
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+	"github.com/BrunoDM2943/go-todo-lambda/internal/module/todo"
+	mock_todo "github.com/BrunoDM2943/go-todo-lambda/internal/module/todo/mock"
+	"github.com/BrunoDM2943/go-todo-lambda/internal/repository"
+	mock_repository "github.com/BrunoDM2943/go-todo-lambda/internal/repository/mock"
+)
+
+var ctx = context.TODO()
+
+var now = time.Date(2021, 3, 21, 8, 30, 0, 0, time.UTC)
+
+type fixedClock struct {
+	time time.Time
+}
+
+func (clock fixedClock) Now() time.Time {
+	return clock.time
+}
+
+type mocks struct {
+	lists       *mock_repository.MockListRepository
+	items       *mock_repository.MockTodoRepository
+	todoService *mock_todo.MockService
+}
+
+func newTestService(ctrl *gomock.Controller) (Service, mocks) {
+	m := mocks{
+		lists:       mock_repository.NewMockListRepository(ctrl),
+		items:       mock_repository.NewMockTodoRepository(ctrl),
+		todoService: mock_todo.NewMockService(ctrl),
+	}
+	return NewListService(m.lists, m.items, m.todoService, WithClock(fixedClock{now})), m
+}
+
+var work = &model.List{ID: "work", Name: "Work", Version: 2}
+
+func TestPostList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, m := newTestService(ctrl)
+
+	t.Run("Success", func(t *testing.T) {
+		m.lists.EXPECT().SaveList(gomock.Any(), &model.List{Name: "Work", CreatedAt: &now, UpdatedAt: &now}).Return(work, nil)
+		list, err := service.PostList(ctx, &model.List{ID: "ignored", Name: "  Work "})
+		assert.Nil(t, err)
+		assert.Equal(t, work, list)
+	})
+
+	t.Run("Name is required", func(t *testing.T) {
+		_, err := service.PostList(ctx, &model.List{Name: " "})
+		assert.True(t, errors.Is(err, todo.ErrValidation))
+	})
+
+	t.Run("Name is too long", func(t *testing.T) {
+		_, err := service.PostList(ctx, &model.List{Name: strings.Repeat("a", MaxNameLength+1)})
+		assert.True(t, errors.Is(err, todo.ErrValidation))
+	})
+}
+
+func TestUpdateList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, m := newTestService(ctrl)
+
+	t.Run("Success", func(t *testing.T) {
+		m.lists.EXPECT().FindListByID(gomock.Any(), "work").Return(work, nil)
+		m.lists.EXPECT().UpdateList(gomock.Any(), &model.List{ID: "work", Name: "Office", UpdatedAt: &now, Version: 2}).Return(work, nil)
+		_, err := service.UpdateList(ctx, "work", 2, &model.List{Name: "Office"})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Stale version", func(t *testing.T) {
+		m.lists.EXPECT().FindListByID(gomock.Any(), "work").Return(work, nil)
+		_, err := service.UpdateList(ctx, "work", 1, &model.List{Name: "Office"})
+		assert.True(t, errors.Is(err, todo.ErrVersionMismatch))
+	})
+}
+
+func TestDeleteList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, m := newTestService(ctrl)
+	inWork := model.ListQuery{Filter: model.ItemFilter{ListID: "work"}, Limit: todo.MaxPageSize}
+
+	t.Run("Empty list", func(t *testing.T) {
+		m.lists.EXPECT().FindListByID(gomock.Any(), "work").Return(work, nil)
+		m.items.EXPECT().List(gomock.Any(), inWork).Return(&model.Page{}, nil)
+		m.lists.EXPECT().DeleteListByID(gomock.Any(), "work", int64(2)).Return(nil)
+		assert.Nil(t, service.DeleteList(ctx, "work", todo.AnyVersion, ""))
+	})
+
+	t.Run("Reject by default", func(t *testing.T) {
+		m.lists.EXPECT().FindListByID(gomock.Any(), "work").Return(work, nil)
+		m.items.EXPECT().List(gomock.Any(), inWork).Return(&model.Page{Items: []*model.Item{{ID: "1"}}}, nil)
+		err := service.DeleteList(ctx, "work", 2, "")
+		assert.True(t, errors.Is(err, todo.ErrConflict))
+	})
+
+	t.Run("Archive", func(t *testing.T) {
+		m.lists.EXPECT().FindListByID(gomock.Any(), "work").Return(work, nil)
+		m.items.EXPECT().List(gomock.Any(), inWork).Return(&model.Page{Items: []*model.Item{{ID: "1", ListID: "work", Version: 3}}, NextCursor: "next"}, nil)
+		next := inWork
+		next.Cursor = "next"
+		m.items.EXPECT().List(gomock.Any(), next).Return(&model.Page{Items: []*model.Item{{ID: "2", ListID: "work", Version: 1}}}, nil)
+		archive := map[string]interface{}{"listId": nil, "archived": true, "updatedAt": now}
+		m.items.EXPECT().Patch(gomock.Any(), "1", int64(3), archive).Return(&model.Item{}, nil)
+		m.items.EXPECT().Patch(gomock.Any(), "2", int64(1), archive).Return(nil, todo.ErrVersionMismatch)
+		m.items.EXPECT().FindByID(gomock.Any(), "2").Return(&model.Item{ID: "2", ListID: "work", Version: 2}, nil)
+		m.items.EXPECT().Patch(gomock.Any(), "2", int64(2), archive).Return(&model.Item{}, nil)
+		m.lists.EXPECT().DeleteListByID(gomock.Any(), "work", int64(2)).Return(nil)
+		assert.Nil(t, service.DeleteList(ctx, "work", 2, CascadeArchive))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		m.lists.EXPECT().FindListByID(gomock.Any(), "work").Return(work, nil)
+		m.items.EXPECT().List(gomock.Any(), inWork).Return(&model.Page{Items: []*model.Item{{ID: "1", ListID: "work", Version: 3}, {ID: "2", ListID: "work", Version: 1}}}, nil)
+		m.todoService.EXPECT().DeleteItem(gomock.Any(), "1", int64(3)).Return(nil)
+		m.todoService.EXPECT().DeleteItem(gomock.Any(), "2", int64(1)).Return(todo.ErrVersionMismatch)
+		m.items.EXPECT().FindByID(gomock.Any(), "2").Return(&model.Item{ID: "2", ListID: "home", Version: 2}, nil)
+		m.lists.EXPECT().DeleteListByID(gomock.Any(), "work", int64(2)).Return(nil)
+		assert.Nil(t, service.DeleteList(ctx, "work", 2, CascadeDelete), "items moved elsewhere meanwhile are left alone")
+	})
+
+	t.Run("Delete unblocks dependents", func(t *testing.T) {
+		repo := repository.NewInMemory()
+		todoService := todo.NewTodoService(repo)
+		service := NewListService(repo.(repository.ListRepository), repo, todoService)
+		list, err := service.PostList(ctx, &model.List{Name: "Work"})
+		assert.Nil(t, err)
+		draft, err := service.AddItem(ctx, list.ID, &model.Item{Title: "draft", Text: "text"})
+		assert.Nil(t, err)
+		report, err := todoService.PostItem(ctx, &model.Item{Title: "report", Text: "text", BlockedBy: []string{draft.ID}})
+		assert.Nil(t, err)
+
+		assert.Nil(t, service.DeleteList(ctx, list.ID, todo.AnyVersion, CascadeDelete))
+		report, err = todoService.GetItem(ctx, report.ID)
+		assert.Nil(t, err)
+		assert.Empty(t, report.BlockedBy)
+	})
+
+	t.Run("Unknown cascade", func(t *testing.T) {
+		err := service.DeleteList(ctx, "work", 2, "orphan")
+		assert.True(t, errors.Is(err, todo.ErrValidation))
+	})
+
+	t.Run("Stale version", func(t *testing.T) {
+		m.lists.EXPECT().FindListByID(gomock.Any(), "work").Return(work, nil)
+		err := service.DeleteList(ctx, "work", 1, CascadeDelete)
+		assert.True(t, errors.Is(err, todo.ErrVersionMismatch))
+	})
+}
+
+func TestListItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, m := newTestService(ctrl)
+
+	t.Run("Get items", func(t *testing.T) {
+		m.lists.EXPECT().FindListByID(gomock.Any(), "work").Return(work, nil)
+		m.todoService.EXPECT().GetItems(gomock.Any(), model.ListQuery{Filter: model.ItemFilter{ListID: "work", Status: model.StatusOpen}, Limit: 5}).Return(&model.Page{}, nil)
+		_, err := service.GetListItems(ctx, "work", model.ListQuery{Filter: model.ItemFilter{Status: model.StatusOpen}, Limit: 5})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Get items of a missing list", func(t *testing.T) {
+		m.lists.EXPECT().FindListByID(gomock.Any(), "gone").Return(nil, todo.ErrNotFound)
+		_, err := service.GetListItems(ctx, "gone", model.ListQuery{})
+		assert.True(t, errors.Is(err, todo.ErrNotFound))
+	})
+
+	t.Run("Add item", func(t *testing.T) {
+		m.lists.EXPECT().FindListByID(gomock.Any(), "work").Return(work, nil)
+		m.todoService.EXPECT().PostItem(gomock.Any(), &model.Item{Title: "Report", Text: "text", ListID: "work"}).Return(&model.Item{ID: "1"}, nil)
+		_, err := service.AddItem(ctx, "work", &model.Item{Title: "Report", Text: "text", Archived: true})
+		assert.Nil(t, err)
+	})
+}
+
+func TestMoveItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, m := newTestService(ctrl)
+
+	t.Run("Into a list", func(t *testing.T) {
+		m.lists.EXPECT().FindListByID(gomock.Any(), "work").Return(work, nil)
+		m.items.EXPECT().FindByID(gomock.Any(), "1").Return(&model.Item{ID: "1", ListID: "home", Version: 4}, nil)
+		m.items.EXPECT().Patch(gomock.Any(), "1", int64(4), map[string]interface{}{"listId": "work", "updatedAt": now}).Return(&model.Item{ID: "1", ListID: "work"}, nil)
+		item, err := service.MoveItem(ctx, "1", "work")
+		assert.Nil(t, err)
+		assert.Equal(t, "work", item.ListID)
+	})
+
+	t.Run("Archived into a list", func(t *testing.T) {
+		m.lists.EXPECT().FindListByID(gomock.Any(), "work").Return(work, nil)
+		m.items.EXPECT().FindByID(gomock.Any(), "1").Return(&model.Item{ID: "1", Archived: true, Version: 4}, nil)
+		m.items.EXPECT().Patch(gomock.Any(), "1", int64(4), map[string]interface{}{"listId": "work", "archived": false, "updatedAt": now}).Return(&model.Item{ID: "1", ListID: "work"}, nil)
+		_, err := service.MoveItem(ctx, "1", "work")
+		assert.Nil(t, err)
+	})
+
+	t.Run("Out of its list", func(t *testing.T) {
+		m.items.EXPECT().FindByID(gomock.Any(), "1").Return(&model.Item{ID: "1", ListID: "home", Version: 4}, nil)
+		m.items.EXPECT().Patch(gomock.Any(), "1", int64(4), map[string]interface{}{"listId": nil, "updatedAt": now}).Return(&model.Item{ID: "1"}, nil)
+		_, err := service.MoveItem(ctx, "1", "")
+		assert.Nil(t, err)
+	})
+
+	t.Run("Already there", func(t *testing.T) {
+		m.lists.EXPECT().FindListByID(gomock.Any(), "work").Return(work, nil)
+		m.items.EXPECT().FindByID(gomock.Any(), "1").Return(&model.Item{ID: "1", ListID: "work"}, nil)
+		_, err := service.MoveItem(ctx, "1", "work")
+		assert.Nil(t, err)
+	})
+
+	t.Run("Missing list", func(t *testing.T) {
+		m.lists.EXPECT().FindListByID(gomock.Any(), "gone").Return(nil, todo.ErrNotFound)
+		_, err := service.MoveItem(ctx, "1", "gone")
+		assert.True(t, errors.Is(err, todo.ErrNotFound))
+	})
+
+	t.Run("Lost race", func(t *testing.T) {
+		m.items.EXPECT().FindByID(gomock.Any(), "1").Return(&model.Item{ID: "1", ListID: "home", Version: 4}, nil)
+		m.items.EXPECT().Patch(gomock.Any(), "1", int64(4), gomock.Any()).Return(nil, todo.ErrVersionMismatch)
+		_, err := service.MoveItem(ctx, "1", "")
+		assert.True(t, errors.Is(err, todo.ErrConflict))
+	})
+}
@@ -0,0 +1,156 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./lists.go
+
+// Package mock_lists is a generated GoMock package.
+package mock_lists
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+	lists "github.com/BrunoDM2943/go-todo-lambda/internal/module/lists"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// AddItem mocks base method.
+func (m *MockService) AddItem(ctx context.Context, id string, item *model.Item) (*model.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddItem", ctx, id, item)
+	ret0, _ := ret[0].(*model.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddItem indicates an expected call of AddItem.
+func (mr *MockServiceMockRecorder) AddItem(ctx, id, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItem", reflect.TypeOf((*MockService)(nil).AddItem), ctx, id, item)
+}
+
+// DeleteList mocks base method.
+func (m *MockService) DeleteList(ctx context.Context, id string, version int64, cascade lists.Cascade) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteList", ctx, id, version, cascade)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteList indicates an expected call of DeleteList.
+func (mr *MockServiceMockRecorder) DeleteList(ctx, id, version, cascade interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteList", reflect.TypeOf((*MockService)(nil).DeleteList), ctx, id, version, cascade)
+}
+
+// GetList mocks base method.
+func (m *MockService) GetList(ctx context.Context, id string) (*model.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", ctx, id)
+	ret0, _ := ret[0].(*model.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockServiceMockRecorder) GetList(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockService)(nil).GetList), ctx, id)
+}
+
+// GetListItems mocks base method.
+func (m *MockService) GetListItems(ctx context.Context, id string, query model.ListQuery) (*model.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListItems", ctx, id, query)
+	ret0, _ := ret[0].(*model.Page)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListItems indicates an expected call of GetListItems.
+func (mr *MockServiceMockRecorder) GetListItems(ctx, id, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListItems", reflect.TypeOf((*MockService)(nil).GetListItems), ctx, id, query)
+}
+
+// GetLists mocks base method.
+func (m *MockService) GetLists(ctx context.Context) ([]*model.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLists", ctx)
+	ret0, _ := ret[0].([]*model.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLists indicates an expected call of GetLists.
+func (mr *MockServiceMockRecorder) GetLists(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLists", reflect.TypeOf((*MockService)(nil).GetLists), ctx)
+}
+
+// MoveItem mocks base method.
+func (m *MockService) MoveItem(ctx context.Context, itemID, listID string) (*model.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveItem", ctx, itemID, listID)
+	ret0, _ := ret[0].(*model.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveItem indicates an expected call of MoveItem.
+func (mr *MockServiceMockRecorder) MoveItem(ctx, itemID, listID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveItem", reflect.TypeOf((*MockService)(nil).MoveItem), ctx, itemID, listID)
+}
+
+// PostList mocks base method.
+func (m *MockService) PostList(ctx context.Context, list *model.List) (*model.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostList", ctx, list)
+	ret0, _ := ret[0].(*model.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostList indicates an expected call of PostList.
+func (mr *MockServiceMockRecorder) PostList(ctx, list interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostList", reflect.TypeOf((*MockService)(nil).PostList), ctx, list)
+}
+
+// UpdateList mocks base method.
+func (m *MockService) UpdateList(ctx context.Context, id string, version int64, list *model.List) (*model.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateList", ctx, id, version, list)
+	ret0, _ := ret[0].(*model.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateList indicates an expected call of UpdateList.
+func (mr *MockServiceMockRecorder) UpdateList(ctx, id, version, list interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateList", reflect.TypeOf((*MockService)(nil).UpdateList), ctx, id, version, list)
+}
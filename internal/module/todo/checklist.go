@@ -0,0 +1,136 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+	"github.com/google/uuid"
+)
+
+const (
+	MaxChecklistEntries    = 50
+	MaxChecklistTextLength = 200
+)
+
+func (service *todoService) AddChecklistEntry(ctx context.Context, id string, text string) (*model.Item, error) {
+	text, err := normalizeEntryText(text)
+	if err != nil {
+		return nil, err
+	}
+	return service.editChecklist(ctx, id, func(entries []model.ChecklistEntry) ([]model.ChecklistEntry, error) {
+		if len(entries) >= MaxChecklistEntries {
+			return nil, fmt.Errorf("%w: item %s already has %d checklist entries", ErrValidation, id, MaxChecklistEntries)
+		}
+		return append(entries, model.ChecklistEntry{ID: uuid.NewString(), Text: text}), nil
+	})
+}
+
+func (service *todoService) ToggleChecklistEntry(ctx context.Context, id string, entryID string) (*model.Item, error) {
+	return service.editChecklist(ctx, id, func(entries []model.ChecklistEntry) ([]model.ChecklistEntry, error) {
+		index, err := findEntry(entries, id, entryID)
+		if err != nil {
+			return nil, err
+		}
+		entries[index].Done = !entries[index].Done
+		return entries, nil
+	})
+}
+
+// MoveChecklistEntry puts an entry at position, shifting the entries between
+// its old and new place by one.
+func (service *todoService) MoveChecklistEntry(ctx context.Context, id string, entryID string, position int) (*model.Item, error) {
+	return service.editChecklist(ctx, id, func(entries []model.ChecklistEntry) ([]model.ChecklistEntry, error) {
+		index, err := findEntry(entries, id, entryID)
+		if err != nil {
+			return nil, err
+		}
+		if position < 0 || position >= len(entries) {
+			return nil, fmt.Errorf("%w: position must be between 0 and %d", ErrValidation, len(entries)-1)
+		}
+		moved := entries[index]
+		entries = append(entries[:index], entries[index+1:]...)
+		entries = append(entries[:position], append([]model.ChecklistEntry{moved}, entries[position:]...)...)
+		return entries, nil
+	})
+}
+
+func (service *todoService) RemoveChecklistEntry(ctx context.Context, id string, entryID string) (*model.Item, error) {
+	return service.editChecklist(ctx, id, func(entries []model.ChecklistEntry) ([]model.ChecklistEntry, error) {
+		index, err := findEntry(entries, id, entryID)
+		if err != nil {
+			return nil, err
+		}
+		return append(entries[:index], entries[index+1:]...), nil
+	})
+}
+
+// editChecklist applies edit to a copy of the item's checklist, numbers the
+// result again and writes it as a transition.
+func (service *todoService) editChecklist(ctx context.Context, id string, edit func([]model.ChecklistEntry) ([]model.ChecklistEntry, error)) (*model.Item, error) {
+	current, err := service.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := edit(append([]model.ChecklistEntry(nil), current.Checklist...))
+	if err != nil {
+		return nil, err
+	}
+	var checklist interface{}
+	if len(entries) > 0 {
+		for position := range entries {
+			entries[position].Position = position
+		}
+		checklist = entries
+	}
+	return service.transition(ctx, current, map[string]interface{}{
+		"checklist": checklist,
+		"updatedAt": service.now(),
+	})
+}
+
+// normalizeChecklist takes the entries of a new item in the order given and
+// assigns their IDs and positions.
+func normalizeChecklist(entries []model.ChecklistEntry) ([]model.ChecklistEntry, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	if len(entries) > MaxChecklistEntries {
+		return nil, fmt.Errorf("%w: at most %d checklist entries are allowed", ErrValidation, MaxChecklistEntries)
+	}
+	normalized := make([]model.ChecklistEntry, 0, len(entries))
+	for position, entry := range entries {
+		text, err := normalizeEntryText(entry.Text)
+		if err != nil {
+			return nil, err
+		}
+		normalized = append(normalized, model.ChecklistEntry{
+			ID:       uuid.NewString(),
+			Text:     text,
+			Done:     entry.Done,
+			Position: position,
+		})
+	}
+	return normalized, nil
+}
+
+func normalizeEntryText(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", fmt.Errorf("%w: checklist entries need a text", ErrValidation)
+	}
+	if len([]rune(text)) > MaxChecklistTextLength {
+		return "", fmt.Errorf("%w: checklist entries are limited to %d characters", ErrValidation, MaxChecklistTextLength)
+	}
+	return text, nil
+}
+
+func findEntry(entries []model.ChecklistEntry, id string, entryID string) (int, error) {
+	for index, entry := range entries {
+		if entry.ID == entryID {
+			return index, nil
+		}
+	}
+	return -1, fmt.Errorf("%w: item %s has no checklist entry %s", ErrNotFound, id, entryID)
+}
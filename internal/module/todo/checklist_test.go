@@ -0,0 +1,138 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+)
+
+func TestChecklist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockRepo := newTestService(ctrl)
+	entries := func() []model.ChecklistEntry {
+		return []model.ChecklistEntry{
+			{ID: "a", Text: "outline", Done: true, Position: 0},
+			{ID: "b", Text: "draft", Position: 1},
+			{ID: "c", Text: "review", Position: 2},
+		}
+	}
+	current := func() *model.Item {
+		return &model.Item{ID: defaultID, Checklist: entries(), Version: 3}
+	}
+	patched := func(item *model.Item) func(context.Context, string, int64, map[string]interface{}) (*model.Item, error) {
+		return func(_ context.Context, _ string, _ int64, fields map[string]interface{}) (*model.Item, error) {
+			item.Checklist, _ = fields["checklist"].([]model.ChecklistEntry)
+			return item, nil
+		}
+	}
+
+	t.Run("Add", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(current(), nil)
+		mockRepo.EXPECT().Patch(gomock.Any(), defaultID, int64(3), gomock.Any()).DoAndReturn(patched(&model.Item{}))
+		item, err := service.AddChecklistEntry(ctx, defaultID, " publish ")
+		assert.Nil(t, err)
+		assert.Len(t, item.Checklist, 4)
+		assert.Equal(t, "publish", item.Checklist[3].Text)
+		assert.Equal(t, 3, item.Checklist[3].Position)
+		assert.NotEmpty(t, item.Checklist[3].ID)
+	})
+
+	t.Run("Add needs a text", func(t *testing.T) {
+		_, err := service.AddChecklistEntry(ctx, defaultID, " ")
+		assert.True(t, errors.Is(err, ErrValidation))
+		_, err = service.AddChecklistEntry(ctx, defaultID, strings.Repeat("a", MaxChecklistTextLength+1))
+		assert.True(t, errors.Is(err, ErrValidation))
+	})
+
+	t.Run("Add to a full checklist", func(t *testing.T) {
+		full := &model.Item{ID: defaultID, Checklist: make([]model.ChecklistEntry, MaxChecklistEntries)}
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(full, nil)
+		_, err := service.AddChecklistEntry(ctx, defaultID, "one more")
+		assert.True(t, errors.Is(err, ErrValidation))
+	})
+
+	t.Run("Toggle", func(t *testing.T) {
+		toggled := entries()
+		toggled[1].Done = true
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(current(), nil)
+		mockRepo.EXPECT().Patch(gomock.Any(), defaultID, int64(3), map[string]interface{}{"checklist": toggled, "updatedAt": now}).Return(&model.Item{}, nil)
+		_, err := service.ToggleChecklistEntry(ctx, defaultID, "b")
+		assert.Nil(t, err)
+	})
+
+	t.Run("Toggle a missing entry", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(current(), nil)
+		_, err := service.ToggleChecklistEntry(ctx, defaultID, "z")
+		assert.True(t, errors.Is(err, ErrNotFound))
+	})
+
+	t.Run("Move", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(current(), nil)
+		mockRepo.EXPECT().Patch(gomock.Any(), defaultID, int64(3), map[string]interface{}{
+			"checklist": []model.ChecklistEntry{
+				{ID: "c", Text: "review", Position: 0},
+				{ID: "a", Text: "outline", Done: true, Position: 1},
+				{ID: "b", Text: "draft", Position: 2},
+			},
+			"updatedAt": now,
+		}).Return(&model.Item{}, nil)
+		_, err := service.MoveChecklistEntry(ctx, defaultID, "c", 0)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Move out of range", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(current(), nil)
+		_, err := service.MoveChecklistEntry(ctx, defaultID, "a", 3)
+		assert.True(t, errors.Is(err, ErrValidation))
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(current(), nil)
+		mockRepo.EXPECT().Patch(gomock.Any(), defaultID, int64(3), map[string]interface{}{
+			"checklist": []model.ChecklistEntry{
+				{ID: "b", Text: "draft", Position: 0},
+				{ID: "c", Text: "review", Position: 1},
+			},
+			"updatedAt": now,
+		}).Return(&model.Item{}, nil)
+		_, err := service.RemoveChecklistEntry(ctx, defaultID, "a")
+		assert.Nil(t, err)
+	})
+
+	t.Run("Remove the last entry", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(&model.Item{ID: defaultID, Checklist: entries()[:1], Version: 3}, nil)
+		mockRepo.EXPECT().Patch(gomock.Any(), defaultID, int64(3), map[string]interface{}{"checklist": nil, "updatedAt": now}).Return(&model.Item{}, nil)
+		_, err := service.RemoveChecklistEntry(ctx, defaultID, "a")
+		assert.Nil(t, err)
+	})
+
+	t.Run("Lost race", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(current(), nil)
+		mockRepo.EXPECT().Patch(gomock.Any(), defaultID, int64(3), gomock.Any()).Return(nil, ErrVersionMismatch)
+		_, err := service.ToggleChecklistEntry(ctx, defaultID, "a")
+		assert.True(t, errors.Is(err, ErrConflict))
+	})
+
+	t.Run("Post assigns IDs and positions", func(t *testing.T) {
+		mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, item *model.Item) (*model.Item, error) {
+			return item, nil
+		})
+		item, err := service.PostItem(ctx, &model.Item{Title: "report", Text: "text", Checklist: []model.ChecklistEntry{
+			{ID: "mine", Text: "outline", Position: 7},
+			{Text: "draft", Done: true},
+		}})
+		assert.Nil(t, err)
+		assert.Len(t, item.Checklist, 2)
+		assert.NotEqual(t, "mine", item.Checklist[0].ID)
+		assert.Equal(t, 0, item.Checklist[0].Position)
+		assert.Equal(t, model.ChecklistEntry{ID: item.Checklist[1].ID, Text: "draft", Done: true, Position: 1}, item.Checklist[1])
+	})
+}
@@ -0,0 +1,173 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+)
+
+const MaxDependencies = 20
+
+// AddDependency makes id wait on blockerID. The blocker has to exist and be
+// open, and may not already wait on id, directly or through other items.
+// Two concurrent calls can still close a cycle between them; the cycle then
+// breaks as soon as one of its items is completed.
+func (service *todoService) AddDependency(ctx context.Context, id string, blockerID string) (*model.Item, error) {
+	if id == blockerID {
+		return nil, fmt.Errorf("%w: item %s cannot block itself", ErrValidation, id)
+	}
+	current, err := service.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if model.ContainsString(current.BlockedBy, blockerID) {
+		return current, nil
+	}
+	if len(current.BlockedBy) >= MaxDependencies {
+		return nil, fmt.Errorf("%w: item %s already waits on %d items", ErrValidation, id, MaxDependencies)
+	}
+	blocker, err := service.findBlocker(ctx, blockerID)
+	if err != nil {
+		return nil, err
+	}
+	path, err := service.findDependencyPath(ctx, blocker, id)
+	if err != nil {
+		return nil, err
+	}
+	if path != nil {
+		return nil, fmt.Errorf("%w: %s would close the cycle %s", ErrValidation, blockerID, strings.Join(append([]string{id}, path...), " -> "))
+	}
+	blockedBy := append(append([]string(nil), current.BlockedBy...), blockerID)
+	sort.Strings(blockedBy)
+	return service.transition(ctx, current, map[string]interface{}{
+		"blockedBy": blockedBy,
+		"updatedAt": service.now(),
+	})
+}
+
+func (service *todoService) RemoveDependency(ctx context.Context, id string, blockerID string) (*model.Item, error) {
+	current, err := service.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !model.ContainsString(current.BlockedBy, blockerID) {
+		return nil, fmt.Errorf("%w: item %s does not wait on %s", ErrNotFound, id, blockerID)
+	}
+	return service.transition(ctx, current, map[string]interface{}{
+		"blockedBy": withoutDependency(current.BlockedBy, blockerID),
+		"updatedAt": service.now(),
+	})
+}
+
+// validateBlockers checks the dependencies of a new item, which nothing can
+// wait on yet, so they cannot form a cycle.
+func (service *todoService) validateBlockers(ctx context.Context, blockers []string) ([]string, error) {
+	if len(blockers) == 0 {
+		return nil, nil
+	}
+	unique := make(map[string]bool, len(blockers))
+	normalized := make([]string, 0, len(blockers))
+	for _, blockerID := range blockers {
+		if unique[blockerID] {
+			continue
+		}
+		unique[blockerID] = true
+		if _, err := service.findBlocker(ctx, blockerID); err != nil {
+			return nil, err
+		}
+		normalized = append(normalized, blockerID)
+	}
+	if len(normalized) > MaxDependencies {
+		return nil, fmt.Errorf("%w: an item can wait on at most %d items", ErrValidation, MaxDependencies)
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+// findBlocker reads an item about to block another. A missing blocker is a
+// mistake in the request rather than a missing resource.
+func (service *todoService) findBlocker(ctx context.Context, blockerID string) (*model.Item, error) {
+	blocker, err := service.repository.FindByID(ctx, blockerID)
+	if errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("%w: item %s does not exist", ErrValidation, blockerID)
+	} else if err != nil {
+		return nil, err
+	}
+	if blocker.Done {
+		return nil, fmt.Errorf("%w: item %s is already completed and cannot block anything", ErrValidation, blockerID)
+	}
+	return blocker, nil
+}
+
+// findDependencyPath walks the items from waits on, breadth first, and
+// returns the IDs leading from it to target, or nil when there is no path.
+// Dependencies on items deleted since are skipped.
+func (service *todoService) findDependencyPath(ctx context.Context, from *model.Item, target string) ([]string, error) {
+	previous := map[string]string{from.ID: ""}
+	queue := []*model.Item{from}
+	for len(queue) > 0 {
+		item := queue[0]
+		queue = queue[1:]
+		for _, next := range item.BlockedBy {
+			if _, seen := previous[next]; seen {
+				continue
+			}
+			previous[next] = item.ID
+			if next == target {
+				path := []string{}
+				for step := next; step != ""; step = previous[step] {
+					path = append([]string{step}, path...)
+				}
+				return path, nil
+			}
+			nextItem, err := service.repository.FindByID(ctx, next)
+			if errors.Is(err, ErrNotFound) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			queue = append(queue, nextItem)
+		}
+	}
+	return nil, nil
+}
+
+// unblockDependents removes a completed or deleted item from everything that
+// waited on it.
+func (service *todoService) unblockDependents(ctx context.Context, id string) error {
+	dependents, err := service.collectItems(ctx, model.ItemFilter{BlockedBy: id})
+	if err != nil {
+		return err
+	}
+	for _, dependent := range dependents {
+		_, err := service.patchWithRetry(ctx, dependent, func(item *model.Item) (map[string]interface{}, bool) {
+			return map[string]interface{}{
+				"blockedBy": withoutDependency(item.BlockedBy, id),
+				"updatedAt": service.now(),
+			}, model.ContainsString(item.BlockedBy, id)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withoutDependency returns nil once nothing is left, which removes the
+// attribute.
+func withoutDependency(blockedBy []string, blockerID string) interface{} {
+	remaining := make([]string, 0, len(blockedBy))
+	for _, id := range blockedBy {
+		if id != blockerID {
+			remaining = append(remaining, id)
+		}
+	}
+	if len(remaining) == 0 {
+		return nil
+	}
+	return remaining
+}
@@ -0,0 +1,129 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+)
+
+func TestDependencies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockRepo := newTestService(ctrl)
+	dependents := model.ListQuery{Filter: model.ItemFilter{BlockedBy: defaultID}, Limit: MaxPageSize}
+
+	t.Run("Add", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), "report").Return(&model.Item{ID: "report", BlockedBy: []string{"review"}, Version: 2}, nil)
+		mockRepo.EXPECT().FindByID(gomock.Any(), "draft").Return(&model.Item{ID: "draft", BlockedBy: []string{"outline"}}, nil)
+		mockRepo.EXPECT().FindByID(gomock.Any(), "outline").Return(&model.Item{ID: "outline"}, nil)
+		mockRepo.EXPECT().Patch(gomock.Any(), "report", int64(2), map[string]interface{}{
+			"blockedBy": []string{"draft", "review"},
+			"updatedAt": now,
+		}).Return(&model.Item{ID: "report"}, nil)
+		_, err := service.AddDependency(ctx, "report", "draft")
+		assert.Nil(t, err)
+	})
+
+	t.Run("Add twice", func(t *testing.T) {
+		current := &model.Item{ID: "report", BlockedBy: []string{"draft"}}
+		mockRepo.EXPECT().FindByID(gomock.Any(), "report").Return(current, nil)
+		item, err := service.AddDependency(ctx, "report", "draft")
+		assert.Nil(t, err)
+		assert.Equal(t, current, item)
+	})
+
+	t.Run("Add a cycle", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), "outline").Return(&model.Item{ID: "outline"}, nil)
+		mockRepo.EXPECT().FindByID(gomock.Any(), "report").Return(&model.Item{ID: "report", BlockedBy: []string{"draft"}}, nil)
+		mockRepo.EXPECT().FindByID(gomock.Any(), "draft").Return(&model.Item{ID: "draft", BlockedBy: []string{"outline"}}, nil)
+		_, err := service.AddDependency(ctx, "outline", "report")
+		assert.True(t, errors.Is(err, ErrValidation))
+		assert.True(t, strings.Contains(err.Error(), "outline -> report -> draft -> outline"))
+	})
+
+	t.Run("Add itself", func(t *testing.T) {
+		_, err := service.AddDependency(ctx, "report", "report")
+		assert.True(t, errors.Is(err, ErrValidation))
+	})
+
+	t.Run("Add a missing item", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), "report").Return(&model.Item{ID: "report"}, nil)
+		mockRepo.EXPECT().FindByID(gomock.Any(), "gone").Return(nil, ErrNotFound)
+		_, err := service.AddDependency(ctx, "report", "gone")
+		assert.True(t, errors.Is(err, ErrValidation))
+	})
+
+	t.Run("Add a completed item", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), "report").Return(&model.Item{ID: "report"}, nil)
+		mockRepo.EXPECT().FindByID(gomock.Any(), "draft").Return(&model.Item{ID: "draft", Done: true}, nil)
+		_, err := service.AddDependency(ctx, "report", "draft")
+		assert.True(t, errors.Is(err, ErrValidation))
+	})
+
+	t.Run("Remove the last", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), "report").Return(&model.Item{ID: "report", BlockedBy: []string{"draft"}, Version: 2}, nil)
+		mockRepo.EXPECT().Patch(gomock.Any(), "report", int64(2), map[string]interface{}{"blockedBy": nil, "updatedAt": now}).Return(&model.Item{ID: "report"}, nil)
+		_, err := service.RemoveDependency(ctx, "report", "draft")
+		assert.Nil(t, err)
+	})
+
+	t.Run("Remove a missing dependency", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), "report").Return(&model.Item{ID: "report", BlockedBy: []string{"draft"}}, nil)
+		_, err := service.RemoveDependency(ctx, "report", "outline")
+		assert.True(t, errors.Is(err, ErrNotFound))
+	})
+
+	t.Run("Post validates blockers", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), "review").Return(&model.Item{ID: "review"}, nil)
+		mockRepo.EXPECT().FindByID(gomock.Any(), "draft").Return(&model.Item{ID: "draft"}, nil)
+		mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, item *model.Item) (*model.Item, error) {
+			return item, nil
+		})
+		item, err := service.PostItem(ctx, &model.Item{Title: "report", Text: "text", BlockedBy: []string{"review", "draft", "review"}})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"draft", "review"}, item.BlockedBy)
+	})
+
+	t.Run("Complete unblocks dependents", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(&model.Item{ID: defaultID, Version: 1}, nil)
+		mockRepo.EXPECT().Patch(gomock.Any(), defaultID, int64(1), gomock.Any()).Return(&model.Item{ID: defaultID, Done: true}, nil)
+		mockRepo.EXPECT().List(gomock.Any(), dependents).Return(&model.Page{Items: []*model.Item{
+			{ID: "report", BlockedBy: []string{defaultID, "review"}, Version: 4},
+			{ID: "draft", BlockedBy: []string{defaultID}, Version: 2},
+		}}, nil)
+		mockRepo.EXPECT().Patch(gomock.Any(), "report", int64(4), map[string]interface{}{"blockedBy": []string{"review"}, "updatedAt": now}).Return(&model.Item{}, nil)
+		mockRepo.EXPECT().Patch(gomock.Any(), "draft", int64(2), gomock.Any()).Return(nil, ErrVersionMismatch)
+		mockRepo.EXPECT().FindByID(gomock.Any(), "draft").Return(&model.Item{ID: "draft", BlockedBy: []string{defaultID}, Version: 3}, nil)
+		mockRepo.EXPECT().Patch(gomock.Any(), "draft", int64(3), map[string]interface{}{"blockedBy": nil, "updatedAt": now}).Return(&model.Item{}, nil)
+		_, err := service.CompleteItem(ctx, defaultID)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Complete again finishes unblocking", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(&model.Item{ID: defaultID, Done: true}, nil)
+		mockRepo.EXPECT().List(gomock.Any(), dependents).Return(&model.Page{Items: []*model.Item{{ID: "draft", BlockedBy: []string{defaultID}, Version: 2}}}, nil)
+		mockRepo.EXPECT().Patch(gomock.Any(), "draft", int64(2), map[string]interface{}{"blockedBy": nil, "updatedAt": now}).Return(&model.Item{}, nil)
+		_, err := service.CompleteItem(ctx, defaultID)
+		assert.True(t, errors.Is(err, ErrConflict))
+	})
+
+	t.Run("Delete fails to unblock", func(t *testing.T) {
+		mockRepo.EXPECT().DeleteByID(gomock.Any(), defaultID, int64(1)).Return(nil)
+		mockRepo.EXPECT().List(gomock.Any(), dependents).Return(nil, errors.New("Error"))
+		assert.NotNil(t, service.DeleteItem(ctx, defaultID, 1))
+	})
+
+	t.Run("Delete again finishes unblocking", func(t *testing.T) {
+		mockRepo.EXPECT().DeleteByID(gomock.Any(), defaultID, int64(1)).Return(ErrNotFound)
+		mockRepo.EXPECT().List(gomock.Any(), dependents).Return(&model.Page{Items: []*model.Item{{ID: "draft", BlockedBy: []string{defaultID}, Version: 2}}}, nil)
+		mockRepo.EXPECT().Patch(gomock.Any(), "draft", int64(2), map[string]interface{}{"blockedBy": nil, "updatedAt": now}).Return(&model.Item{}, nil)
+		assert.True(t, errors.Is(service.DeleteItem(ctx, defaultID, 1), ErrNotFound))
+	})
+}
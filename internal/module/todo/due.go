@@ -0,0 +1,87 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+)
+
+const (
+	DefaultUpcomingWindow = 24 * time.Hour
+	MaxUpcomingWindow     = 90 * 24 * time.Hour
+)
+
+const anchorSeparator = "~"
+
+// GetOverdueItems lists open items whose due date has passed, soonest first.
+func (service *todoService) GetOverdueItems(ctx context.Context, query model.ListQuery) (*model.Page, error) {
+	return service.listDue(ctx, query, func(now time.Time) model.ItemFilter {
+		return model.ItemFilter{Status: model.StatusOpen, Archived: unarchived(), DueBefore: &now}
+	})
+}
+
+// GetUpcomingItems lists open items due from now until within from now,
+// soonest first. A zero window means DefaultUpcomingWindow.
+func (service *todoService) GetUpcomingItems(ctx context.Context, within time.Duration, query model.ListQuery) (*model.Page, error) {
+	if within == 0 {
+		within = DefaultUpcomingWindow
+	}
+	if within < 0 || within > MaxUpcomingWindow {
+		return nil, fmt.Errorf("%w: within must be positive and at most %s", ErrValidation, MaxUpcomingWindow)
+	}
+	return service.listDue(ctx, query, func(now time.Time) model.ItemFilter {
+		until := now.Add(within)
+		return model.ItemFilter{Status: model.StatusOpen, Archived: unarchived(), DueAfter: &now, DueBefore: &until}
+	})
+}
+
+// listDue pins the window to the time the first page was read, so later pages
+// continue the same listing instead of one that moved with the clock. The
+// instant travels in front of the repository cursor; tampering with it
+// changes the filter the cursor was signed for, which the repository rejects.
+func (service *todoService) listDue(ctx context.Context, query model.ListQuery, window func(now time.Time) model.ItemFilter) (*model.Page, error) {
+	now := service.now()
+	if query.Cursor != "" {
+		anchor, cursor, err := splitAnchor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		now, query.Cursor = anchor, cursor
+	}
+	query.Filter = window(now)
+	query.Sort = model.SortOrder{Field: model.SortByDueDate}
+	page, err := service.GetItems(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if page.NextCursor != "" {
+		page.NextCursor = strconv.FormatInt(now.UnixNano()/int64(time.Millisecond), 36) + anchorSeparator + page.NextCursor
+	}
+	return page, nil
+}
+
+func splitAnchor(cursor string) (time.Time, string, error) {
+	parts := strings.SplitN(cursor, anchorSeparator, 2)
+	if len(parts) != 2 {
+		return time.Time{}, "", fmt.Errorf("%w: invalid cursor", ErrValidation)
+	}
+	millis, err := strconv.ParseInt(parts[0], 36, 64)
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("%w: invalid cursor", ErrValidation)
+	}
+	return time.Unix(0, millis*int64(time.Millisecond)).UTC(), parts[1], nil
+}
+
+// normalizeDue stores due dates as UTC instants with second precision; the
+// offset a client sent is only needed to tell which instant it meant.
+func normalizeDue(due *time.Time) *time.Time {
+	if due == nil {
+		return nil
+	}
+	normalized := due.UTC().Truncate(time.Second)
+	return &normalized
+}
@@ -0,0 +1,108 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+)
+
+func TestGetOverdueItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockRepo := newTestService(ctrl)
+	byDueDate := model.SortOrder{Field: model.SortByDueDate}
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().List(gomock.Any(), model.ListQuery{
+			Filter: model.ItemFilter{Status: model.StatusOpen, Archived: unarchived(), DueBefore: &now},
+			Sort:   byDueDate,
+			Limit:  DefaultPageSize,
+		}).Return(allItems, nil)
+		result, err := service.GetOverdueItems(ctx, model.ListQuery{})
+		assert.Nil(t, err)
+		assert.Equal(t, allItems, result)
+	})
+
+	t.Run("Success - Next page keeps the window", func(t *testing.T) {
+		mockRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(&model.Page{Items: []*model.Item{}, NextCursor: "next"}, nil)
+		first, err := service.GetOverdueItems(ctx, model.ListQuery{Limit: 1})
+		assert.Nil(t, err)
+
+		later := NewTodoService(mockRepo, WithClock(fixedClock{now.Add(time.Hour)}))
+		mockRepo.EXPECT().List(gomock.Any(), model.ListQuery{
+			Filter: model.ItemFilter{Status: model.StatusOpen, Archived: unarchived(), DueBefore: &now},
+			Sort:   byDueDate,
+			Limit:  1,
+			Cursor: "next",
+		}).Return(allItems, nil)
+		_, err = later.GetOverdueItems(ctx, model.ListQuery{Limit: 1, Cursor: first.NextCursor})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Fail - Invalid cursor", func(t *testing.T) {
+		_, err := service.GetOverdueItems(ctx, model.ListQuery{Cursor: "next"})
+		assert.True(t, errors.Is(err, ErrValidation))
+	})
+}
+
+func TestPostItemDueDate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockRepo := newTestService(ctrl)
+	mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, item *model.Item) (*model.Item, error) {
+		return item, nil
+	})
+	dueAt := time.Date(2021, 3, 20, 18, 0, 0, 500, time.FixedZone("", -3*60*60))
+	item, err := service.PostItem(ctx, &model.Item{Title: "List", Text: "Homework", DueAt: &dueAt})
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2021, 3, 20, 21, 0, 0, 0, time.UTC), *item.DueAt, "the offset is not kept")
+}
+
+func TestGetUpcomingItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockRepo := newTestService(ctrl)
+	byDueDate := model.SortOrder{Field: model.SortByDueDate}
+
+	t.Run("Success", func(t *testing.T) {
+		until := now.Add(48 * time.Hour)
+		mockRepo.EXPECT().List(gomock.Any(), model.ListQuery{
+			Filter: model.ItemFilter{Status: model.StatusOpen, Archived: unarchived(), DueAfter: &now, DueBefore: &until},
+			Sort:   byDueDate,
+			Limit:  10,
+		}).Return(allItems, nil)
+		result, err := service.GetUpcomingItems(ctx, 48*time.Hour, model.ListQuery{Limit: 10})
+		assert.Nil(t, err)
+		assert.Equal(t, allItems, result)
+	})
+
+	t.Run("Success - Default window", func(t *testing.T) {
+		until := now.Add(DefaultUpcomingWindow)
+		mockRepo.EXPECT().List(gomock.Any(), model.ListQuery{
+			Filter: model.ItemFilter{Status: model.StatusOpen, Archived: unarchived(), DueAfter: &now, DueBefore: &until},
+			Sort:   byDueDate,
+			Limit:  DefaultPageSize,
+		}).Return(allItems, nil)
+		_, err := service.GetUpcomingItems(ctx, 0, model.ListQuery{})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Fail - Negative window", func(t *testing.T) {
+		_, err := service.GetUpcomingItems(ctx, -time.Hour, model.ListQuery{})
+		assert.True(t, errors.Is(err, ErrValidation))
+	})
+
+	t.Run("Fail - Window too long", func(t *testing.T) {
+		_, err := service.GetUpcomingItems(ctx, MaxUpcomingWindow+time.Hour, model.ListQuery{})
+		assert.True(t, errors.Is(err, ErrValidation))
+	})
+}
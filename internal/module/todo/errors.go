@@ -0,0 +1,12 @@
+package todo
+
+import "github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+
+// The sentinels are declared in model so that repositories can return them
+// without importing this package. Callers should match them with errors.Is.
+var (
+	ErrNotFound        = model.ErrNotFound
+	ErrValidation      = model.ErrValidation
+	ErrConflict        = model.ErrConflict
+	ErrVersionMismatch = model.ErrVersionMismatch
+)
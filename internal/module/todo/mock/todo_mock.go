@@ -5,7 +5,9 @@
 package mock_todo
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
 	gomock "github.com/golang/mock/gomock"
@@ -34,60 +36,338 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// AddChecklistEntry mocks base method.
+func (m *MockService) AddChecklistEntry(ctx context.Context, id, text string) (*model.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddChecklistEntry", ctx, id, text)
+	ret0, _ := ret[0].(*model.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddChecklistEntry indicates an expected call of AddChecklistEntry.
+func (mr *MockServiceMockRecorder) AddChecklistEntry(ctx, id, text interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddChecklistEntry", reflect.TypeOf((*MockService)(nil).AddChecklistEntry), ctx, id, text)
+}
+
+// AddDependency mocks base method.
+func (m *MockService) AddDependency(ctx context.Context, id, blockerID string) (*model.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDependency", ctx, id, blockerID)
+	ret0, _ := ret[0].(*model.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddDependency indicates an expected call of AddDependency.
+func (mr *MockServiceMockRecorder) AddDependency(ctx, id, blockerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDependency", reflect.TypeOf((*MockService)(nil).AddDependency), ctx, id, blockerID)
+}
+
+// CompleteItem mocks base method.
+func (m *MockService) CompleteItem(ctx context.Context, id string) (*model.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteItem", ctx, id)
+	ret0, _ := ret[0].(*model.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteItem indicates an expected call of CompleteItem.
+func (mr *MockServiceMockRecorder) CompleteItem(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteItem", reflect.TypeOf((*MockService)(nil).CompleteItem), ctx, id)
+}
+
 // DeleteItem mocks base method.
-func (m *MockService) DeleteItem(id string) error {
+func (m *MockService) DeleteItem(ctx context.Context, id string, version int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteItem", id)
+	ret := m.ctrl.Call(m, "DeleteItem", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteItem indicates an expected call of DeleteItem.
-func (mr *MockServiceMockRecorder) DeleteItem(id interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) DeleteItem(ctx, id, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockService)(nil).DeleteItem), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockService)(nil).DeleteItem), ctx, id, version)
 }
 
 // GetItem mocks base method.
-func (m *MockService) GetItem(id string) (*model.Item, error) {
+func (m *MockService) GetItem(ctx context.Context, id string) (*model.Item, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetItem", id)
+	ret := m.ctrl.Call(m, "GetItem", ctx, id)
 	ret0, _ := ret[0].(*model.Item)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetItem indicates an expected call of GetItem.
-func (mr *MockServiceMockRecorder) GetItem(id interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) GetItem(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItem", reflect.TypeOf((*MockService)(nil).GetItem), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItem", reflect.TypeOf((*MockService)(nil).GetItem), ctx, id)
 }
 
 // GetItems mocks base method.
-func (m *MockService) GetItems() ([]*model.Item, error) {
+func (m *MockService) GetItems(ctx context.Context, query model.ListQuery) (*model.Page, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetItems")
-	ret0, _ := ret[0].([]*model.Item)
+	ret := m.ctrl.Call(m, "GetItems", ctx, query)
+	ret0, _ := ret[0].(*model.Page)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetItems indicates an expected call of GetItems.
-func (mr *MockServiceMockRecorder) GetItems() *gomock.Call {
+func (mr *MockServiceMockRecorder) GetItems(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItems", reflect.TypeOf((*MockService)(nil).GetItems), ctx, query)
+}
+
+// GetNextItems mocks base method.
+func (m *MockService) GetNextItems(ctx context.Context, limit int) (*model.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNextItems", ctx, limit)
+	ret0, _ := ret[0].(*model.Page)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNextItems indicates an expected call of GetNextItems.
+func (mr *MockServiceMockRecorder) GetNextItems(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextItems", reflect.TypeOf((*MockService)(nil).GetNextItems), ctx, limit)
+}
+
+// GetOverdueItems mocks base method.
+func (m *MockService) GetOverdueItems(ctx context.Context, query model.ListQuery) (*model.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverdueItems", ctx, query)
+	ret0, _ := ret[0].(*model.Page)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverdueItems indicates an expected call of GetOverdueItems.
+func (mr *MockServiceMockRecorder) GetOverdueItems(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverdueItems", reflect.TypeOf((*MockService)(nil).GetOverdueItems), ctx, query)
+}
+
+// GetTags mocks base method.
+func (m *MockService) GetTags(ctx context.Context) ([]model.TagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTags", ctx)
+	ret0, _ := ret[0].([]model.TagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTags indicates an expected call of GetTags.
+func (mr *MockServiceMockRecorder) GetTags(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockService)(nil).GetTags), ctx)
+}
+
+// GetUpcomingItems mocks base method.
+func (m *MockService) GetUpcomingItems(ctx context.Context, within time.Duration, query model.ListQuery) (*model.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpcomingItems", ctx, within, query)
+	ret0, _ := ret[0].(*model.Page)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpcomingItems indicates an expected call of GetUpcomingItems.
+func (mr *MockServiceMockRecorder) GetUpcomingItems(ctx, within, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpcomingItems", reflect.TypeOf((*MockService)(nil).GetUpcomingItems), ctx, within, query)
+}
+
+// MergeTags mocks base method.
+func (m *MockService) MergeTags(ctx context.Context, sources []string, into string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeTags", ctx, sources, into)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeTags indicates an expected call of MergeTags.
+func (mr *MockServiceMockRecorder) MergeTags(ctx, sources, into interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeTags", reflect.TypeOf((*MockService)(nil).MergeTags), ctx, sources, into)
+}
+
+// MoveChecklistEntry mocks base method.
+func (m *MockService) MoveChecklistEntry(ctx context.Context, id, entryID string, position int) (*model.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveChecklistEntry", ctx, id, entryID, position)
+	ret0, _ := ret[0].(*model.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveChecklistEntry indicates an expected call of MoveChecklistEntry.
+func (mr *MockServiceMockRecorder) MoveChecklistEntry(ctx, id, entryID, position interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveChecklistEntry", reflect.TypeOf((*MockService)(nil).MoveChecklistEntry), ctx, id, entryID, position)
+}
+
+// PatchItem mocks base method.
+func (m *MockService) PatchItem(ctx context.Context, id string, version int64, patch map[string]interface{}) (*model.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchItem", ctx, id, version, patch)
+	ret0, _ := ret[0].(*model.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchItem indicates an expected call of PatchItem.
+func (mr *MockServiceMockRecorder) PatchItem(ctx, id, version, patch interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItems", reflect.TypeOf((*MockService)(nil).GetItems))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchItem", reflect.TypeOf((*MockService)(nil).PatchItem), ctx, id, version, patch)
 }
 
 // PostItem mocks base method.
-func (m *MockService) PostItem(item *model.Item) error {
+func (m *MockService) PostItem(ctx context.Context, item *model.Item) (*model.Item, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PostItem", item)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "PostItem", ctx, item)
+	ret0, _ := ret[0].(*model.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // PostItem indicates an expected call of PostItem.
-func (mr *MockServiceMockRecorder) PostItem(item interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) PostItem(ctx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostItem", reflect.TypeOf((*MockService)(nil).PostItem), ctx, item)
+}
+
+// RemoveChecklistEntry mocks base method.
+func (m *MockService) RemoveChecklistEntry(ctx context.Context, id, entryID string) (*model.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveChecklistEntry", ctx, id, entryID)
+	ret0, _ := ret[0].(*model.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveChecklistEntry indicates an expected call of RemoveChecklistEntry.
+func (mr *MockServiceMockRecorder) RemoveChecklistEntry(ctx, id, entryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveChecklistEntry", reflect.TypeOf((*MockService)(nil).RemoveChecklistEntry), ctx, id, entryID)
+}
+
+// RemoveDependency mocks base method.
+func (m *MockService) RemoveDependency(ctx context.Context, id, blockerID string) (*model.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDependency", ctx, id, blockerID)
+	ret0, _ := ret[0].(*model.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveDependency indicates an expected call of RemoveDependency.
+func (mr *MockServiceMockRecorder) RemoveDependency(ctx, id, blockerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDependency", reflect.TypeOf((*MockService)(nil).RemoveDependency), ctx, id, blockerID)
+}
+
+// RenameTag mocks base method.
+func (m *MockService) RenameTag(ctx context.Context, from, to string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameTag", ctx, from, to)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameTag indicates an expected call of RenameTag.
+func (mr *MockServiceMockRecorder) RenameTag(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameTag", reflect.TypeOf((*MockService)(nil).RenameTag), ctx, from, to)
+}
+
+// ReopenItem mocks base method.
+func (m *MockService) ReopenItem(ctx context.Context, id string) (*model.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReopenItem", ctx, id)
+	ret0, _ := ret[0].(*model.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReopenItem indicates an expected call of ReopenItem.
+func (mr *MockServiceMockRecorder) ReopenItem(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReopenItem", reflect.TypeOf((*MockService)(nil).ReopenItem), ctx, id)
+}
+
+// ToggleChecklistEntry mocks base method.
+func (m *MockService) ToggleChecklistEntry(ctx context.Context, id, entryID string) (*model.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToggleChecklistEntry", ctx, id, entryID)
+	ret0, _ := ret[0].(*model.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ToggleChecklistEntry indicates an expected call of ToggleChecklistEntry.
+func (mr *MockServiceMockRecorder) ToggleChecklistEntry(ctx, id, entryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToggleChecklistEntry", reflect.TypeOf((*MockService)(nil).ToggleChecklistEntry), ctx, id, entryID)
+}
+
+// UpdateItem mocks base method.
+func (m *MockService) UpdateItem(ctx context.Context, id string, version int64, item *model.Item) (*model.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItem", ctx, id, version, item)
+	ret0, _ := ret[0].(*model.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateItem indicates an expected call of UpdateItem.
+func (mr *MockServiceMockRecorder) UpdateItem(ctx, id, version, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockService)(nil).UpdateItem), ctx, id, version, item)
+}
+
+// MockClock is a mock of Clock interface.
+type MockClock struct {
+	ctrl     *gomock.Controller
+	recorder *MockClockMockRecorder
+}
+
+// MockClockMockRecorder is the mock recorder for MockClock.
+type MockClockMockRecorder struct {
+	mock *MockClock
+}
+
+// NewMockClock creates a new mock instance.
+func NewMockClock(ctrl *gomock.Controller) *MockClock {
+	mock := &MockClock{ctrl: ctrl}
+	mock.recorder = &MockClockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClock) EXPECT() *MockClockMockRecorder {
+	return m.recorder
+}
+
+// Now mocks base method.
+func (m *MockClock) Now() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Now")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// Now indicates an expected call of Now.
+func (mr *MockClockMockRecorder) Now() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostItem", reflect.TypeOf((*MockService)(nil).PostItem), item)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Now", reflect.TypeOf((*MockClock)(nil).Now))
 }
@@ -0,0 +1,112 @@
+package todo
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+)
+
+var patchableFields = map[string]bool{
+	"title":    true,
+	"text":     true,
+	"dueAt":    true,
+	"tags":     true,
+	"priority": true,
+	"archived": true,
+}
+
+// applyMergePatch applies an RFC 7396 merge patch to the item and returns
+// the top-level attributes that changed, with nil marking a removal.
+func applyMergePatch(item *model.Item, patch map[string]interface{}) (map[string]interface{}, error) {
+	document, err := toDocument(item)
+	if err != nil {
+		return nil, err
+	}
+	for field, value := range patch {
+		if !patchableFields[field] {
+			return nil, fmt.Errorf("%w: field %s cannot be patched", ErrValidation, field)
+		}
+		if merged := mergePatch(document[field], value); merged == nil {
+			delete(document, field)
+		} else {
+			document[field] = merged
+		}
+	}
+
+	if due, ok := document["dueAt"].(string); ok {
+		if _, err := time.Parse(time.RFC3339, due); err != nil {
+			return nil, fmt.Errorf("%w: dueAt must be an RFC 3339 timestamp with a time zone", ErrValidation)
+		}
+	}
+	patched := &model.Item{}
+	if err := fromDocument(document, patched); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrValidation, err.Error())
+	}
+	if patched.Title == "" || patched.Text == "" {
+		return nil, fmt.Errorf("%w: title and text are required", ErrValidation)
+	}
+	patched.DueAt = normalizeDue(patched.DueAt)
+	if patched.Priority, err = normalizePriority(patched.Priority); err != nil {
+		return nil, err
+	}
+	if patched.Tags, err = normalizeTags(patched.Tags); err != nil {
+		return nil, err
+	}
+
+	normalized, err := toDocument(patched)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]interface{}, len(patch))
+	for field := range patch {
+		fields[field] = normalized[field]
+	}
+	// Repositories store tags as sets and need them typed; no tags at all
+	// removes the attribute.
+	if _, ok := fields["tags"]; ok {
+		fields["tags"] = nil
+		if patched.Tags != nil {
+			fields["tags"] = patched.Tags
+		}
+	}
+	return fields, nil
+}
+
+func mergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+		} else {
+			targetObject[key] = mergePatch(targetObject[key], value)
+		}
+	}
+	return targetObject
+}
+
+func toDocument(item *model.Item) (map[string]interface{}, error) {
+	raw, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	document := map[string]interface{}{}
+	err = json.Unmarshal(raw, &document)
+	return document, err
+}
+
+func fromDocument(document map[string]interface{}, item *model.Item) error {
+	raw, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, item)
+}
@@ -0,0 +1,109 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+)
+
+// Ranker scores an open item for the next actions view; higher scores come
+// first. Rankers must be pure, they are called once per item and request.
+type Ranker func(item *model.Item, now time.Time) float64
+
+func WithRanker(ranker Ranker) Option {
+	return func(service *todoService) {
+		service.ranker = ranker
+	}
+}
+
+var priorityLevels = map[string]float64{
+	model.PriorityP0: 3,
+	model.PriorityP1: 2,
+	model.PriorityP2: 1,
+	model.PriorityP3: 0,
+}
+
+// RankByUrgency is the default Ranker. Each priority level is worth 10
+// points, a due date adds up to 10 more as it approaches and the full 10 once
+// it has passed, and age adds up to 1 point over a month to break ties in
+// favour of what has waited longest. An overdue P2 thus ranks with a P1.
+func RankByUrgency(item *model.Item, now time.Time) float64 {
+	priority, ok := priorityLevels[item.Priority]
+	if !ok {
+		priority = priorityLevels[model.DefaultPriority]
+	}
+	score := 10 * priority
+
+	if item.DueAt != nil {
+		days := item.DueAt.Sub(now).Hours() / 24
+		if days <= 0 {
+			score += 10
+		} else {
+			score += 10 / (1 + days)
+		}
+	}
+
+	if item.CreatedAt != nil {
+		age := now.Sub(*item.CreatedAt).Hours() / 24 / 30
+		if age > 1 {
+			age = 1
+		}
+		if age > 0 {
+			score += age
+		}
+	}
+	return score
+}
+
+// GetNextItems answers "what should I work on now": the open, unblocked items
+// with the highest rank. Ranks depend on the current time, so every candidate
+// is read and scored.
+func (service *todoService) GetNextItems(ctx context.Context, limit int) (*model.Page, error) {
+	query := model.ListQuery{
+		Filter: model.ItemFilter{Status: model.StatusOpen, Archived: unarchived(), Blocked: unblocked()},
+		Limit:  limit,
+	}
+	if err := validateQuery(query); err != nil {
+		return nil, err
+	}
+	if limit == 0 {
+		limit = DefaultPageSize
+	} else if limit > MaxPageSize {
+		limit = MaxPageSize
+	}
+
+	open, err := service.collectItems(ctx, query.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	now := service.now()
+	scores := make(map[string]float64, len(open))
+	for _, item := range open {
+		scores[item.ID] = service.ranker(item, now)
+	}
+	sort.SliceStable(open, func(i, j int) bool {
+		if scores[open[i].ID] != scores[open[j].ID] {
+			return scores[open[i].ID] > scores[open[j].ID]
+		}
+		return open[i].ID < open[j].ID
+	})
+	if len(open) > limit {
+		open = open[:limit]
+	}
+	return &model.Page{Items: open}, nil
+}
+
+// normalizePriority defaults a missing priority and rejects unknown ones.
+func normalizePriority(priority string) (string, error) {
+	if priority == "" {
+		return model.DefaultPriority, nil
+	}
+	if !model.Priorities[priority] {
+		return "", fmt.Errorf("%w: priority must be one of P0, P1, P2 or P3", ErrValidation)
+	}
+	return priority, nil
+}
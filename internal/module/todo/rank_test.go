@@ -0,0 +1,99 @@
+package todo
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+	mock_repository "github.com/BrunoDM2943/go-todo-lambda/internal/repository/mock"
+)
+
+func TestRankByUrgency(t *testing.T) {
+	at := func(offset time.Duration) *time.Time {
+		instant := now.Add(offset)
+		return &instant
+	}
+
+	t.Run("Priority dominates", func(t *testing.T) {
+		urgent := RankByUrgency(&model.Item{Priority: model.PriorityP0}, now)
+		later := RankByUrgency(&model.Item{Priority: model.PriorityP1, DueAt: at(72 * time.Hour)}, now)
+		assert.Greater(t, urgent, later)
+	})
+
+	t.Run("Missing priority is the default", func(t *testing.T) {
+		assert.Equal(t, RankByUrgency(&model.Item{Priority: model.DefaultPriority}, now), RankByUrgency(&model.Item{}, now))
+	})
+
+	t.Run("Closer due dates rank higher", func(t *testing.T) {
+		tomorrow := RankByUrgency(&model.Item{DueAt: at(24 * time.Hour)}, now)
+		nextWeek := RankByUrgency(&model.Item{DueAt: at(7 * 24 * time.Hour)}, now)
+		overdue := RankByUrgency(&model.Item{DueAt: at(-time.Hour)}, now)
+		assert.Greater(t, tomorrow, nextWeek)
+		assert.Greater(t, overdue, tomorrow)
+	})
+
+	t.Run("Overdue item catches up one priority level", func(t *testing.T) {
+		overdue := RankByUrgency(&model.Item{Priority: model.PriorityP2, DueAt: at(-time.Hour)}, now)
+		assert.Equal(t, RankByUrgency(&model.Item{Priority: model.PriorityP1}, now), overdue)
+	})
+
+	t.Run("Age breaks ties", func(t *testing.T) {
+		old := RankByUrgency(&model.Item{CreatedAt: at(-20 * 24 * time.Hour)}, now)
+		fresh := RankByUrgency(&model.Item{CreatedAt: at(-time.Hour)}, now)
+		assert.Greater(t, old, fresh)
+		assert.Less(t, old-fresh, 1.0)
+	})
+}
+
+func TestGetNextItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockRepo := newTestService(ctrl)
+	open := model.ItemFilter{Status: model.StatusOpen, Archived: unarchived(), Blocked: unblocked()}
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().List(gomock.Any(), model.ListQuery{Filter: open, Limit: MaxPageSize}).Return(&model.Page{
+			Items:      []*model.Item{{ID: "low", Priority: model.PriorityP3}, {ID: "high", Priority: model.PriorityP0}},
+			NextCursor: "next",
+		}, nil)
+		mockRepo.EXPECT().List(gomock.Any(), model.ListQuery{Filter: open, Limit: MaxPageSize, Cursor: "next"}).Return(&model.Page{
+			Items: []*model.Item{{ID: "medium", Priority: model.PriorityP1}},
+		}, nil)
+		page, err := service.GetNextItems(ctx, 2)
+		assert.Nil(t, err)
+		assert.Len(t, page.Items, 2)
+		assert.Equal(t, "high", page.Items[0].ID)
+		assert.Equal(t, "medium", page.Items[1].ID)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("Success - Custom ranker", func(t *testing.T) {
+		mockRepo := mock_repository.NewMockTodoRepository(ctrl)
+		byTitle := func(item *model.Item, now time.Time) float64 {
+			return -float64(item.Title[0])
+		}
+		service := NewTodoService(mockRepo, WithClock(fixedClock{now}), WithRanker(byTitle))
+		mockRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(&model.Page{
+			Items: []*model.Item{{ID: "1", Title: "b", Priority: model.PriorityP0}, {ID: "2", Title: "a"}},
+		}, nil)
+		page, err := service.GetNextItems(ctx, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, "2", page.Items[0].ID)
+	})
+
+	t.Run("Fail - Negative limit", func(t *testing.T) {
+		_, err := service.GetNextItems(ctx, -1)
+		assert.True(t, errors.Is(err, ErrValidation))
+	})
+
+	t.Run("Fail", func(t *testing.T) {
+		mockRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, errors.New("Error"))
+		_, err := service.GetNextItems(ctx, 0)
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,126 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+)
+
+const (
+	MaxTags      = 20
+	MaxTagLength = 50
+)
+
+func (service *todoService) GetTags(ctx context.Context) ([]model.TagCount, error) {
+	return service.repository.CountTags(ctx)
+}
+
+// RenameTag renames a tag on every item carrying it and returns how many
+// items changed. Renaming onto a tag that is already in use is a merge and
+// has to be asked for with MergeTags.
+func (service *todoService) RenameTag(ctx context.Context, from string, to string) (int, error) {
+	from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+	if _, err := normalizeTags([]string{from, to}); err != nil {
+		return 0, err
+	}
+	if from == to {
+		return 0, fmt.Errorf("%w: tag %s would be renamed to itself", ErrValidation, from)
+	}
+	used, err := service.repository.List(ctx, model.ListQuery{Filter: model.ItemFilter{Tags: []string{to}}, Limit: 1})
+	if err != nil {
+		return 0, err
+	}
+	if len(used.Items) > 0 {
+		return 0, fmt.Errorf("%w: tag %s is already in use, merge the tags instead", ErrConflict, to)
+	}
+	return service.retag(ctx, []string{from}, to)
+}
+
+// MergeTags replaces each of the sources by into on every item carrying one
+// of them and returns how many items changed.
+func (service *todoService) MergeTags(ctx context.Context, sources []string, into string) (int, error) {
+	if len(sources) == 0 {
+		return 0, fmt.Errorf("%w: no tags to merge", ErrValidation)
+	}
+	sources, err := normalizeTags(sources)
+	if err != nil {
+		return 0, err
+	}
+	into = strings.TrimSpace(into)
+	if _, err := normalizeTags([]string{into}); err != nil {
+		return 0, err
+	}
+	return service.retag(ctx, sources, into)
+}
+
+// retag rewrites the affected items one by one.
+func (service *todoService) retag(ctx context.Context, sources []string, target string) (int, error) {
+	affected, err := service.collectItems(ctx, model.ItemFilter{Tags: sources, TagMatch: model.TagMatchAny})
+	if err != nil {
+		return 0, err
+	}
+	if len(affected) == 0 {
+		return 0, fmt.Errorf("%w: no item is tagged %s", ErrNotFound, strings.Join(sources, ", "))
+	}
+
+	changed := 0
+	for _, item := range affected {
+		updated, err := service.patchWithRetry(ctx, item, func(item *model.Item) (map[string]interface{}, bool) {
+			tags, replaced := replaceTags(item.Tags, sources, target)
+			return map[string]interface{}{
+				"tags":      tags,
+				"updatedAt": service.now(),
+			}, replaced
+		})
+		if err != nil {
+			return changed, err
+		}
+		if updated {
+			changed++
+		}
+	}
+	return changed, nil
+}
+
+func replaceTags(tags []string, sources []string, target string) ([]string, bool) {
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if model.ContainsString(sources, tag) {
+			tag = target
+		}
+		result = append(result, tag)
+	}
+	result, _ = normalizeTags(result)
+	if strings.Join(result, "\x00") == strings.Join(tags, "\x00") {
+		return tags, false
+	}
+	return result, true
+}
+
+// normalizeTags trims, deduplicates and sorts tags, so the same set of tags
+// is always stored the same way. No tags at all is nil.
+func normalizeTags(tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	unique := map[string]bool{}
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || len(tag) > MaxTagLength {
+			return nil, fmt.Errorf("%w: tags must have between 1 and %d characters", ErrValidation, MaxTagLength)
+		}
+		unique[tag] = true
+	}
+	if len(unique) > MaxTags {
+		return nil, fmt.Errorf("%w: an item can have at most %d tags", ErrValidation, MaxTags)
+	}
+	normalized := make([]string, 0, len(unique))
+	for tag := range unique {
+		normalized = append(normalized, tag)
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
@@ -0,0 +1,126 @@
+package todo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+)
+
+func TestGetTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockRepo := newTestService(ctrl)
+
+	t.Run("Success", func(t *testing.T) {
+		counts := []model.TagCount{{Name: "work", Count: 2}}
+		mockRepo.EXPECT().CountTags(gomock.Any()).Return(counts, nil)
+		result, err := service.GetTags(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, counts, result)
+	})
+}
+
+func TestRenameTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockRepo := newTestService(ctrl)
+	unused := model.ListQuery{Filter: model.ItemFilter{Tags: []string{"chores"}}, Limit: 1}
+	tagged := model.ListQuery{Filter: model.ItemFilter{Tags: []string{"home"}, TagMatch: model.TagMatchAny}, Limit: MaxPageSize}
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().List(gomock.Any(), unused).Return(&model.Page{}, nil)
+		mockRepo.EXPECT().List(gomock.Any(), tagged).Return(&model.Page{Items: []*model.Item{
+			{ID: "a", Tags: []string{"home", "urgent"}, Version: 1},
+			{ID: "b", Tags: []string{"home"}, Version: 4},
+		}}, nil)
+		mockRepo.EXPECT().Patch(gomock.Any(), "a", int64(1), map[string]interface{}{"tags": []string{"chores", "urgent"}, "updatedAt": now}).Return(&model.Item{}, nil)
+		mockRepo.EXPECT().Patch(gomock.Any(), "b", int64(4), map[string]interface{}{"tags": []string{"chores"}, "updatedAt": now}).Return(&model.Item{}, nil)
+		changed, err := service.RenameTag(ctx, "home", " chores ")
+		assert.Nil(t, err)
+		assert.Equal(t, 2, changed)
+	})
+
+	t.Run("Success - Item changed concurrently", func(t *testing.T) {
+		mockRepo.EXPECT().List(gomock.Any(), unused).Return(&model.Page{}, nil)
+		mockRepo.EXPECT().List(gomock.Any(), tagged).Return(&model.Page{Items: []*model.Item{{ID: "a", Tags: []string{"home"}, Version: 1}}}, nil)
+		mockRepo.EXPECT().Patch(gomock.Any(), "a", int64(1), gomock.Any()).Return(nil, ErrVersionMismatch)
+		mockRepo.EXPECT().FindByID(gomock.Any(), "a").Return(&model.Item{ID: "a", Tags: []string{"home", "work"}, Version: 2}, nil)
+		mockRepo.EXPECT().Patch(gomock.Any(), "a", int64(2), map[string]interface{}{"tags": []string{"chores", "work"}, "updatedAt": now}).Return(&model.Item{}, nil)
+		changed, err := service.RenameTag(ctx, "home", "chores")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, changed)
+	})
+
+	t.Run("Fail - Target in use", func(t *testing.T) {
+		mockRepo.EXPECT().List(gomock.Any(), unused).Return(&model.Page{Items: []*model.Item{{}}}, nil)
+		_, err := service.RenameTag(ctx, "home", "chores")
+		assert.True(t, errors.Is(err, ErrConflict))
+	})
+
+	t.Run("Fail - Unknown tag", func(t *testing.T) {
+		mockRepo.EXPECT().List(gomock.Any(), unused).Return(&model.Page{}, nil)
+		mockRepo.EXPECT().List(gomock.Any(), tagged).Return(&model.Page{}, nil)
+		_, err := service.RenameTag(ctx, "home", "chores")
+		assert.True(t, errors.Is(err, ErrNotFound))
+	})
+
+	t.Run("Fail - Empty name", func(t *testing.T) {
+		_, err := service.RenameTag(ctx, "home", " ")
+		assert.True(t, errors.Is(err, ErrValidation))
+	})
+}
+
+func TestMergeTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockRepo := newTestService(ctrl)
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().List(gomock.Any(), model.ListQuery{
+			Filter: model.ItemFilter{Tags: []string{"chores", "home"}, TagMatch: model.TagMatchAny},
+			Limit:  MaxPageSize,
+		}).Return(&model.Page{Items: []*model.Item{
+			{ID: "a", Tags: []string{"chores", "home"}, Version: 1},
+			{ID: "b", Tags: []string{"house"}, Version: 1},
+		}}, nil)
+		mockRepo.EXPECT().Patch(gomock.Any(), "a", int64(1), map[string]interface{}{"tags": []string{"house"}, "updatedAt": now}).Return(&model.Item{}, nil)
+		changed, err := service.MergeTags(ctx, []string{"home", "chores"}, "house")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, changed, "items already carrying only the target are left alone")
+	})
+
+	t.Run("Fail - Nothing to merge", func(t *testing.T) {
+		_, err := service.MergeTags(ctx, nil, "house")
+		assert.True(t, errors.Is(err, ErrValidation))
+	})
+}
+
+func TestNormalizeTags(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		tags, err := normalizeTags([]string{"work", " home", "work"})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"home", "work"}, tags)
+	})
+
+	t.Run("Success - No tags", func(t *testing.T) {
+		tags, err := normalizeTags([]string{})
+		assert.Nil(t, err)
+		assert.Nil(t, tags)
+	})
+
+	t.Run("Fail - Too many", func(t *testing.T) {
+		tags := make([]string, 0, MaxTags+1)
+		for i := 0; i <= MaxTags; i++ {
+			tags = append(tags, string(rune('a'+i)))
+		}
+		_, err := normalizeTags(tags)
+		assert.True(t, errors.Is(err, ErrValidation))
+	})
+}
@@ -1,38 +1,375 @@
 package todo
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
 	"github.com/BrunoDM2943/go-todo-lambda/internal/repository"
 )
 
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// AnyVersion can be passed instead of an item version to write whatever
+// version is current, like If-Match: *.
+const AnyVersion int64 = -1
+
 //go:generate mockgen -source=./todo.go -destination=./mock/todo_mock.go
 type Service interface {
-	PostItem(item *model.Item) error
-	GetItem(id string) (*model.Item, error)
-	GetItems() ([]*model.Item, error)
-	DeleteItem(id string) error
+	PostItem(ctx context.Context, item *model.Item) (*model.Item, error)
+	GetItem(ctx context.Context, id string) (*model.Item, error)
+	GetItems(ctx context.Context, query model.ListQuery) (*model.Page, error)
+	GetOverdueItems(ctx context.Context, query model.ListQuery) (*model.Page, error)
+	GetUpcomingItems(ctx context.Context, within time.Duration, query model.ListQuery) (*model.Page, error)
+	GetTags(ctx context.Context) ([]model.TagCount, error)
+	RenameTag(ctx context.Context, from string, to string) (int, error)
+	MergeTags(ctx context.Context, sources []string, into string) (int, error)
+	GetNextItems(ctx context.Context, limit int) (*model.Page, error)
+	UpdateItem(ctx context.Context, id string, version int64, item *model.Item) (*model.Item, error)
+	PatchItem(ctx context.Context, id string, version int64, patch map[string]interface{}) (*model.Item, error)
+	AddChecklistEntry(ctx context.Context, id string, text string) (*model.Item, error)
+	ToggleChecklistEntry(ctx context.Context, id string, entryID string) (*model.Item, error)
+	MoveChecklistEntry(ctx context.Context, id string, entryID string, position int) (*model.Item, error)
+	RemoveChecklistEntry(ctx context.Context, id string, entryID string) (*model.Item, error)
+	AddDependency(ctx context.Context, id string, blockerID string) (*model.Item, error)
+	RemoveDependency(ctx context.Context, id string, blockerID string) (*model.Item, error)
+	CompleteItem(ctx context.Context, id string) (*model.Item, error)
+	ReopenItem(ctx context.Context, id string) (*model.Item, error)
+	DeleteItem(ctx context.Context, id string, version int64) error
+}
+
+// Clock tells the service what time it is, so tests can fix it.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the clock services use unless told otherwise.
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
 }
 
 type todoService struct {
 	repository repository.TodoRepository
+	clock      Clock
+	ranker     Ranker
+}
+
+type Option func(*todoService)
+
+func WithClock(clock Clock) Option {
+	return func(service *todoService) {
+		service.clock = clock
+	}
+}
+
+func NewTodoService(repository repository.TodoRepository, options ...Option) Service {
+	service := &todoService{
+		repository: repository,
+		clock:      SystemClock{},
+		ranker:     RankByUrgency,
+	}
+	for _, option := range options {
+		option(service)
+	}
+	return service
+}
+
+func (service *todoService) PostItem(ctx context.Context, item *model.Item) (*model.Item, error) {
+	tags, err := normalizeTags(item.Tags)
+	if err != nil {
+		return nil, err
+	}
+	priority, err := normalizePriority(item.Priority)
+	if err != nil {
+		return nil, err
+	}
+	checklist, err := normalizeChecklist(item.Checklist)
+	if err != nil {
+		return nil, err
+	}
+	blockedBy, err := service.validateBlockers(ctx, item.BlockedBy)
+	if err != nil {
+		return nil, err
+	}
+	now := service.now()
+	newItem := *item
+	newItem.Tags = tags
+	newItem.Checklist = checklist
+	newItem.BlockedBy = blockedBy
+	newItem.Priority = priority
+	newItem.Done = false
+	newItem.CompletedAt = nil
+	newItem.DueAt = normalizeDue(item.DueAt)
+	newItem.CreatedAt = &now
+	newItem.UpdatedAt = &now
+	return service.repository.Save(ctx, &newItem)
+}
+
+func (service *todoService) GetItem(ctx context.Context, id string) (*model.Item, error) {
+	return service.repository.FindByID(ctx, id)
+}
+
+func (service *todoService) GetItems(ctx context.Context, query model.ListQuery) (*model.Page, error) {
+	if err := validateQuery(query); err != nil {
+		return nil, err
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultPageSize
+	} else if query.Limit > MaxPageSize {
+		query.Limit = MaxPageSize
+	}
+	return service.repository.List(ctx, query)
+}
+
+func (service *todoService) UpdateItem(ctx context.Context, id string, version int64, item *model.Item) (*model.Item, error) {
+	tags, err := normalizeTags(item.Tags)
+	if err != nil {
+		return nil, err
+	}
+	priority, err := normalizePriority(item.Priority)
+	if err != nil {
+		return nil, err
+	}
+	current, err := service.findAtVersion(ctx, id, version)
+	if err != nil {
+		return nil, err
+	}
+	now := service.now()
+	updated := *item
+	updated.ID = id
+	updated.Tags = tags
+	updated.Priority = priority
+	updated.DueAt = normalizeDue(item.DueAt)
+	updated.CreatedAt = current.CreatedAt
+	updated.UpdatedAt = &now
+	updated.Done = current.Done
+	updated.CompletedAt = current.CompletedAt
+	updated.ListID = current.ListID
+	updated.Checklist = current.Checklist
+	updated.BlockedBy = current.BlockedBy
+	updated.Archived = current.Archived
+	updated.Version = current.Version
+	return service.repository.Update(ctx, &updated)
+}
+
+func (service *todoService) PatchItem(ctx context.Context, id string, version int64, patch map[string]interface{}) (*model.Item, error) {
+	current, err := service.findAtVersion(ctx, id, version)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := applyMergePatch(current, patch)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return current, nil
+	}
+	fields["updatedAt"] = service.now()
+	return service.repository.Patch(ctx, id, current.Version, fields)
+}
+
+func (service *todoService) CompleteItem(ctx context.Context, id string) (*model.Item, error) {
+	current, err := service.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if current.Done {
+		// Completing again finishes the unblocking if it failed the first time.
+		if err := service.unblockDependents(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: item %s is already completed", ErrConflict, id)
+	}
+	now := service.now()
+	completed, err := service.transition(ctx, current, map[string]interface{}{
+		"done":        true,
+		"completedAt": now,
+		"updatedAt":   now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := service.unblockDependents(ctx, id); err != nil {
+		return nil, fmt.Errorf("item %s was completed, but unblocking its dependents failed: %w", id, err)
+	}
+	return completed, nil
+}
+
+func (service *todoService) ReopenItem(ctx context.Context, id string) (*model.Item, error) {
+	current, err := service.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !current.Done {
+		return nil, fmt.Errorf("%w: item %s is not completed", ErrConflict, id)
+	}
+	return service.transition(ctx, current, map[string]interface{}{
+		"done":        false,
+		"completedAt": nil,
+		"updatedAt":   service.now(),
+	})
+}
+
+// transition writes a state change against the version it was checked on.
+// Callers did not ask for a version, so losing a race is a conflict.
+func (service *todoService) transition(ctx context.Context, current *model.Item, fields map[string]interface{}) (*model.Item, error) {
+	item, err := service.repository.Patch(ctx, current.ID, current.Version, fields)
+	if errors.Is(err, ErrVersionMismatch) {
+		return nil, fmt.Errorf("%w: item %s changed concurrently", ErrConflict, current.ID)
+	}
+	return item, err
+}
+
+// writeAttempts bounds how often RetryWrite re-reads an item that keeps
+// changing underneath it.
+const writeAttempts = 3
+
+// RetryWrite runs write against item and, when the item changed since it was
+// read, reads it again and runs write once more. It serves changes nobody
+// pinned to a version, such as those applied to many items at once. Items
+// deleted in the meantime are skipped. write reports whether it changed the
+// item, and so does RetryWrite.
+func RetryWrite(ctx context.Context, items repository.TodoRepository, item *model.Item, write func(*model.Item) (bool, error)) (bool, error) {
+	for attempt := 0; attempt < writeAttempts; attempt++ {
+		if attempt > 0 {
+			current, err := items.FindByID(ctx, item.ID)
+			if errors.Is(err, ErrNotFound) {
+				return false, nil
+			} else if err != nil {
+				return false, err
+			}
+			item = current
+		}
+		changed, err := write(item)
+		switch {
+		case err == nil:
+			return changed, nil
+		case errors.Is(err, ErrNotFound):
+			return false, nil
+		case !errors.Is(err, ErrVersionMismatch):
+			return false, err
+		}
+	}
+	return false, fmt.Errorf("%w: item %s kept changing while it was rewritten", ErrConflict, item.ID)
+}
+
+// patchWithRetry patches the fields build returns for the item as last read,
+// unless build reports that the item needs no change.
+func (service *todoService) patchWithRetry(ctx context.Context, item *model.Item, build func(*model.Item) (map[string]interface{}, bool)) (bool, error) {
+	return RetryWrite(ctx, service.repository, item, func(item *model.Item) (bool, error) {
+		fields, ok := build(item)
+		if !ok {
+			return false, nil
+		}
+		_, err := service.repository.Patch(ctx, item.ID, item.Version, fields)
+		return err == nil, err
+	})
+}
+
+func (service *todoService) collectItems(ctx context.Context, filter model.ItemFilter) ([]*model.Item, error) {
+	return CollectItems(ctx, service.repository, filter)
+}
+
+// CollectItems reads every item matching the filter, page by page. Callers
+// that go on to change the items collect them first, so the listing is not
+// disturbed by their own writes.
+func CollectItems(ctx context.Context, items repository.TodoRepository, filter model.ItemFilter) ([]*model.Item, error) {
+	query := model.ListQuery{
+		Filter: filter,
+		Limit:  MaxPageSize,
+	}
+	collected := make([]*model.Item, 0)
+	for {
+		page, err := items.List(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		collected = append(collected, page.Items...)
+		if page.NextCursor == "" {
+			return collected, nil
+		}
+		query.Cursor = page.NextCursor
+	}
+}
+
+func (service *todoService) DeleteItem(ctx context.Context, id string, version int64) error {
+	err := service.deleteAtVersion(ctx, id, version)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	// Deleting again finds the item gone and finishes the unblocking if it
+	// failed the first time.
+	if failed := service.unblockDependents(ctx, id); failed != nil {
+		return fmt.Errorf("item %s is gone, but unblocking its dependents failed: %w", id, failed)
+	}
+	return err
+}
+
+func (service *todoService) deleteAtVersion(ctx context.Context, id string, version int64) error {
+	if version == AnyVersion {
+		current, err := service.repository.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		version = current.Version
+	}
+	return service.repository.DeleteByID(ctx, id, version)
 }
 
-func NewTodoService(repository repository.TodoRepository) Service {
-	return &todoService{repository}
+// findAtVersion fails early when the caller edits a version that is no longer
+// current. The repository checks again when writing.
+func (service *todoService) findAtVersion(ctx context.Context, id string, version int64) (*model.Item, error) {
+	current, err := service.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if version != AnyVersion && version != current.Version {
+		return nil, fmt.Errorf("%w: item %s is at version %d", ErrVersionMismatch, id, current.Version)
+	}
+	return current, nil
 }
 
-func (service *todoService) PostItem(item *model.Item) error {
-	return service.repository.Save(item)
+// now is kept to millisecond precision, which every backend stores without
+// rounding.
+func (service *todoService) now() time.Time {
+	return service.clock.Now().UTC().Truncate(time.Millisecond)
 }
 
-func (service *todoService) GetItem(id string) (*model.Item, error) {
-	return service.repository.FindByID(id)
+// unarchived filters out archived items, which the built-in views leave out.
+func unarchived() *bool {
+	archived := false
+	return &archived
 }
 
-func (service *todoService) GetItems() ([]*model.Item, error) {
-	return service.repository.ListAll()
+func validateQuery(query model.ListQuery) error {
+	if query.Limit < 0 {
+		return fmt.Errorf("%w: limit must be positive", ErrValidation)
+	}
+	switch query.Filter.Status {
+	case "", model.StatusOpen, model.StatusDone:
+	default:
+		return fmt.Errorf("%w: unknown status %s", ErrValidation, query.Filter.Status)
+	}
+	switch query.Filter.TagMatch {
+	case "", model.TagMatchAll, model.TagMatchAny:
+	default:
+		return fmt.Errorf("%w: unknown tag match %s, expected all or any", ErrValidation, query.Filter.TagMatch)
+	}
+	if query.Sort.Field != "" && !model.SortableFields[query.Sort.Field] {
+		return fmt.Errorf("%w: cannot sort by %s", ErrValidation, query.Sort.Field)
+	}
+	return nil
 }
 
-func (service *todoService) DeleteItem(id string) error {
-	return service.repository.DeleteByID(id)
+// unblocked filters out items still waiting on others.
+func unblocked() *bool {
+	blocked := false
+	return &blocked
 }
@@ -1,8 +1,10 @@
 package todo
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -11,30 +13,83 @@ import (
 	mock_repository "github.com/BrunoDM2943/go-todo-lambda/internal/repository/mock"
 )
 
+var ctx = context.TODO()
+
 var item = &model.Item{}
-var allItems = []*model.Item{
-	{}, {},
+var allItems = &model.Page{
+	Items: []*model.Item{
+		{}, {},
+	},
 }
 
 const defaultID = "XPTO"
 
+var now = time.Date(2021, 3, 21, 8, 30, 0, 0, time.UTC)
+
+type fixedClock struct {
+	time time.Time
+}
+
+func (clock fixedClock) Now() time.Time {
+	return clock.time
+}
+
+func newTestService(ctrl *gomock.Controller) (Service, *mock_repository.MockTodoRepository) {
+	mockRepo := mock_repository.NewMockTodoRepository(ctrl)
+	return NewTodoService(mockRepo, WithClock(fixedClock{now})), mockRepo
+}
+
 func TestPostItem(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockRepo := mock_repository.NewMockTodoRepository(ctrl)
-	service := NewTodoService(mockRepo)
+	service, mockRepo := newTestService(ctrl)
 
 	t.Run("Success", func(t *testing.T) {
-		mockRepo.EXPECT().Save(item).Return(nil)
-		err := service.PostItem(item)
+		stored := &model.Item{ID: defaultID}
+		mockRepo.EXPECT().Save(gomock.Any(), &model.Item{Priority: model.DefaultPriority, CreatedAt: &now, UpdatedAt: &now}).Return(stored, nil)
+		created, err := service.PostItem(ctx, item)
+		assert.Nil(t, err)
+		assert.Equal(t, stored, created)
+	})
+
+	t.Run("Success - Does not mutate the input", func(t *testing.T) {
+		input := &model.Item{Title: "List", Text: "Homework", Done: true, CreatedAt: &time.Time{}}
+		mockRepo.EXPECT().Save(gomock.Any(), &model.Item{Title: "List", Text: "Homework", Priority: model.DefaultPriority, CreatedAt: &now, UpdatedAt: &now}).Return(&model.Item{ID: defaultID}, nil)
+		_, err := service.PostItem(ctx, input)
+		assert.Nil(t, err)
+		assert.True(t, input.Done)
+	})
+
+	t.Run("Success - Tags normalized", func(t *testing.T) {
+		mockRepo.EXPECT().Save(gomock.Any(), &model.Item{Tags: []string{"home", "work"}, Priority: model.DefaultPriority, CreatedAt: &now, UpdatedAt: &now}).Return(&model.Item{ID: defaultID}, nil)
+		_, err := service.PostItem(ctx, &model.Item{Tags: []string{"work ", "home", "work"}})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Fail - Unknown priority", func(t *testing.T) {
+		_, err := service.PostItem(ctx, &model.Item{Priority: "P9"})
+		assert.True(t, errors.Is(err, ErrValidation))
+	})
+
+	t.Run("Fail - Empty tag", func(t *testing.T) {
+		_, err := service.PostItem(ctx, &model.Item{Tags: []string{""}})
+		assert.True(t, errors.Is(err, ErrValidation))
+	})
+
+	t.Run("Success - Due date in UTC", func(t *testing.T) {
+		dueAt := time.Date(2021, 3, 22, 18, 0, 0, 500, time.FixedZone("BRT", -3*60*60))
+		stored := time.Date(2021, 3, 22, 21, 0, 0, 0, time.UTC)
+		mockRepo.EXPECT().Save(gomock.Any(), &model.Item{DueAt: &stored, Priority: model.DefaultPriority, CreatedAt: &now, UpdatedAt: &now}).Return(&model.Item{ID: defaultID}, nil)
+		_, err := service.PostItem(ctx, &model.Item{DueAt: &dueAt})
 		assert.Nil(t, err)
 	})
 
 	t.Run("Fail", func(t *testing.T) {
-		mockRepo.EXPECT().Save(item).Return(errors.New("Error"))
-		err := service.PostItem(item)
+		mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil, errors.New("Error"))
+		created, err := service.PostItem(ctx, item)
 		assert.NotNil(t, err)
+		assert.Nil(t, created)
 	})
 }
 
@@ -42,26 +97,25 @@ func TestGetItem(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockRepo := mock_repository.NewMockTodoRepository(ctrl)
-	service := NewTodoService(mockRepo)
+	service, mockRepo := newTestService(ctrl)
 
 	t.Run("Success", func(t *testing.T) {
-		mockRepo.EXPECT().FindByID(defaultID).Return(item, nil)
-		foundItem, err := service.GetItem(defaultID)
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(item, nil)
+		foundItem, err := service.GetItem(ctx, defaultID)
 		assert.Nil(t, err)
 		assert.Equal(t, item, foundItem)
 	})
 
 	t.Run("Fail - Not found", func(t *testing.T) {
-		mockRepo.EXPECT().FindByID(defaultID).Return(nil, nil)
-		foundItem, err := service.GetItem(defaultID)
-		assert.Nil(t, err)
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(nil, ErrNotFound)
+		foundItem, err := service.GetItem(ctx, defaultID)
+		assert.True(t, errors.Is(err, ErrNotFound))
 		assert.Nil(t, foundItem)
 	})
 
 	t.Run("Fail - Error", func(t *testing.T) {
-		mockRepo.EXPECT().FindByID(defaultID).Return(nil, errors.New("Error"))
-		foundItem, err := service.GetItem(defaultID)
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(nil, errors.New("Error"))
+		foundItem, err := service.GetItem(ctx, defaultID)
 		assert.NotNil(t, err)
 		assert.Nil(t, foundItem)
 	})
@@ -71,17 +125,35 @@ func TestDeleteItem(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockRepo := mock_repository.NewMockTodoRepository(ctrl)
-	service := NewTodoService(mockRepo)
+	service, mockRepo := newTestService(ctrl)
 
 	t.Run("Success", func(t *testing.T) {
-		mockRepo.EXPECT().DeleteByID(defaultID).Return(nil)
-		assert.Nil(t, service.DeleteItem(defaultID))
+		mockRepo.EXPECT().DeleteByID(gomock.Any(), defaultID, int64(1)).Return(nil)
+		mockRepo.EXPECT().List(gomock.Any(), model.ListQuery{Filter: model.ItemFilter{BlockedBy: defaultID}, Limit: MaxPageSize}).Return(&model.Page{}, nil)
+		assert.Nil(t, service.DeleteItem(ctx, defaultID, 1))
+	})
+
+	t.Run("Success - Any version", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(&model.Item{ID: defaultID, Version: 4}, nil)
+		mockRepo.EXPECT().DeleteByID(gomock.Any(), defaultID, int64(4)).Return(nil)
+		mockRepo.EXPECT().List(gomock.Any(), model.ListQuery{Filter: model.ItemFilter{BlockedBy: defaultID}, Limit: MaxPageSize}).Return(&model.Page{}, nil)
+		assert.Nil(t, service.DeleteItem(ctx, defaultID, AnyVersion))
 	})
 
 	t.Run("Fail", func(t *testing.T) {
-		mockRepo.EXPECT().DeleteByID(defaultID).Return(errors.New("Error"))
-		assert.NotNil(t, service.DeleteItem(defaultID))
+		mockRepo.EXPECT().DeleteByID(gomock.Any(), defaultID, int64(1)).Return(errors.New("Error"))
+		assert.NotNil(t, service.DeleteItem(ctx, defaultID, 1))
+	})
+
+	t.Run("Fail - Not found", func(t *testing.T) {
+		mockRepo.EXPECT().DeleteByID(gomock.Any(), defaultID, int64(1)).Return(ErrNotFound)
+		mockRepo.EXPECT().List(gomock.Any(), model.ListQuery{Filter: model.ItemFilter{BlockedBy: defaultID}, Limit: MaxPageSize}).Return(&model.Page{}, nil)
+		assert.True(t, errors.Is(service.DeleteItem(ctx, defaultID, 1), ErrNotFound))
+	})
+
+	t.Run("Fail - Stale version", func(t *testing.T) {
+		mockRepo.EXPECT().DeleteByID(gomock.Any(), defaultID, int64(1)).Return(ErrVersionMismatch)
+		assert.True(t, errors.Is(service.DeleteItem(ctx, defaultID, 1), ErrVersionMismatch))
 	})
 }
 
@@ -89,20 +161,263 @@ func TestGetItems(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockRepo := mock_repository.NewMockTodoRepository(ctrl)
-	service := NewTodoService(mockRepo)
+	service, mockRepo := newTestService(ctrl)
 
 	t.Run("Success", func(t *testing.T) {
-		mockRepo.EXPECT().ListAll().Return(allItems, nil)
-		items, err := service.GetItems()
+		query := model.ListQuery{Limit: 10, Cursor: "next"}
+		mockRepo.EXPECT().List(gomock.Any(), query).Return(allItems, nil)
+		items, err := service.GetItems(ctx, query)
 		assert.Nil(t, err)
 		assert.Equal(t, allItems, items)
 	})
 
+	t.Run("Success - Default limit", func(t *testing.T) {
+		mockRepo.EXPECT().List(gomock.Any(), model.ListQuery{Limit: DefaultPageSize}).Return(allItems, nil)
+		_, err := service.GetItems(ctx, model.ListQuery{})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Success - Limit capped", func(t *testing.T) {
+		mockRepo.EXPECT().List(gomock.Any(), model.ListQuery{Limit: MaxPageSize}).Return(allItems, nil)
+		_, err := service.GetItems(ctx, model.ListQuery{Limit: 1000})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Success - Filter and sort", func(t *testing.T) {
+		query := model.ListQuery{
+			Filter: model.ItemFilter{Status: model.StatusOpen, Query: "home"},
+			Sort:   model.SortOrder{Field: model.SortByTitle, Descending: true},
+			Limit:  10,
+		}
+		mockRepo.EXPECT().List(gomock.Any(), query).Return(allItems, nil)
+		_, err := service.GetItems(ctx, query)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Fail - Unknown status", func(t *testing.T) {
+		_, err := service.GetItems(ctx, model.ListQuery{Filter: model.ItemFilter{Status: "archived"}})
+		assert.True(t, errors.Is(err, ErrValidation))
+	})
+
+	t.Run("Fail - Unknown tag match", func(t *testing.T) {
+		_, err := service.GetItems(ctx, model.ListQuery{Filter: model.ItemFilter{Tags: []string{"work"}, TagMatch: "some"}})
+		assert.True(t, errors.Is(err, ErrValidation))
+	})
+
+	t.Run("Fail - Unknown sort field", func(t *testing.T) {
+		_, err := service.GetItems(ctx, model.ListQuery{Sort: model.SortOrder{Field: "ID"}})
+		assert.True(t, errors.Is(err, ErrValidation))
+	})
+
 	t.Run("Fail", func(t *testing.T) {
-		mockRepo.EXPECT().ListAll().Return(nil, errors.New("Error"))
-		items, err := service.GetItems()
+		mockRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, errors.New("Error"))
+		items, err := service.GetItems(ctx, model.ListQuery{})
 		assert.NotNil(t, err)
 		assert.Nil(t, items)
 	})
 }
+
+func TestUpdateItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockRepo := newTestService(ctrl)
+	completedAt := time.Date(2021, 3, 20, 10, 0, 0, 0, time.UTC)
+
+	t.Run("Success", func(t *testing.T) {
+		input := &model.Item{Title: "List", Text: "Homework", CreatedAt: &now, UpdatedAt: &completedAt, Version: 9}
+		updated := &model.Item{ID: defaultID, Title: "List", Text: "Homework", Done: true, CompletedAt: &completedAt, Version: 2}
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(&model.Item{ID: defaultID, Done: true, CompletedAt: &completedAt, CreatedAt: &completedAt, Version: 1}, nil)
+		mockRepo.EXPECT().Update(gomock.Any(), &model.Item{ID: defaultID, Title: "List", Text: "Homework", Done: true, Priority: model.DefaultPriority, CompletedAt: &completedAt, CreatedAt: &completedAt, UpdatedAt: &now, Version: 1}).Return(updated, nil)
+		result, err := service.UpdateItem(ctx, defaultID, 1, input)
+		assert.Nil(t, err)
+		assert.Equal(t, updated, result)
+		assert.Empty(t, input.ID, "UpdateItem must not mutate its argument")
+	})
+
+	t.Run("Keeps list and archive flag", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(&model.Item{ID: defaultID, ListID: "work", Archived: true, Version: 1}, nil)
+		mockRepo.EXPECT().Update(gomock.Any(), &model.Item{ID: defaultID, Title: "List", Text: "Homework", Priority: model.DefaultPriority, ListID: "work", Archived: true, UpdatedAt: &now, Version: 1}).Return(&model.Item{}, nil)
+		_, err := service.UpdateItem(ctx, defaultID, 1, &model.Item{Title: "List", Text: "Homework", ListID: "home"})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Fail - Not found", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(nil, ErrNotFound)
+		_, err := service.UpdateItem(ctx, defaultID, 1, &model.Item{})
+		assert.True(t, errors.Is(err, ErrNotFound))
+	})
+
+	t.Run("Fail - Stale version", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(&model.Item{ID: defaultID, Version: 2}, nil)
+		_, err := service.UpdateItem(ctx, defaultID, 1, &model.Item{})
+		assert.True(t, errors.Is(err, ErrVersionMismatch))
+	})
+
+	t.Run("Fail - Error", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(&model.Item{ID: defaultID, Version: 1}, nil)
+		mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, errors.New("Error"))
+		_, err := service.UpdateItem(ctx, defaultID, AnyVersion, &model.Item{})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestPatchItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockRepo := newTestService(ctrl)
+	stored := &model.Item{ID: defaultID, Title: "List", Text: "Homework", Version: 1}
+
+	t.Run("Success", func(t *testing.T) {
+		patched := &model.Item{ID: defaultID, Title: "Groceries", Text: "Homework", Version: 2}
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(stored, nil)
+		mockRepo.EXPECT().Patch(gomock.Any(), defaultID, int64(1), map[string]interface{}{"title": "Groceries", "updatedAt": now}).Return(patched, nil)
+		result, err := service.PatchItem(ctx, defaultID, 1, map[string]interface{}{"title": "Groceries"})
+		assert.Nil(t, err)
+		assert.Equal(t, patched, result)
+	})
+
+	t.Run("Success - Due date", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(stored, nil)
+		mockRepo.EXPECT().Patch(gomock.Any(), defaultID, int64(1), map[string]interface{}{"dueAt": "2021-03-22T21:00:00Z", "updatedAt": now}).Return(stored, nil)
+		_, err := service.PatchItem(ctx, defaultID, 1, map[string]interface{}{"dueAt": "2021-03-22T18:00:00.250-03:00"})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Success - Tags", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(stored, nil)
+		mockRepo.EXPECT().Patch(gomock.Any(), defaultID, int64(1), map[string]interface{}{"tags": []string{"home", "work"}, "updatedAt": now}).Return(stored, nil)
+		_, err := service.PatchItem(ctx, defaultID, 1, map[string]interface{}{"tags": []interface{}{"work", "home", "work"}})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Success - Remove tags", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(&model.Item{ID: defaultID, Title: "List", Text: "Homework", Tags: []string{"home"}, Version: 1}, nil)
+		mockRepo.EXPECT().Patch(gomock.Any(), defaultID, int64(1), map[string]interface{}{"tags": nil, "updatedAt": now}).Return(stored, nil)
+		_, err := service.PatchItem(ctx, defaultID, 1, map[string]interface{}{"tags": []interface{}{}})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Success - Priority reset to default", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(&model.Item{ID: defaultID, Title: "List", Text: "Homework", Priority: model.PriorityP0, Version: 1}, nil)
+		mockRepo.EXPECT().Patch(gomock.Any(), defaultID, int64(1), map[string]interface{}{"priority": model.DefaultPriority, "updatedAt": now}).Return(stored, nil)
+		_, err := service.PatchItem(ctx, defaultID, 1, map[string]interface{}{"priority": nil})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Success - Remove due date", func(t *testing.T) {
+		dueAt := now.Add(time.Hour)
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(&model.Item{ID: defaultID, Title: "List", Text: "Homework", DueAt: &dueAt, Version: 1}, nil)
+		mockRepo.EXPECT().Patch(gomock.Any(), defaultID, int64(1), map[string]interface{}{"dueAt": nil, "updatedAt": now}).Return(stored, nil)
+		_, err := service.PatchItem(ctx, defaultID, 1, map[string]interface{}{"dueAt": nil})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Success - Empty patch", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(stored, nil)
+		result, err := service.PatchItem(ctx, defaultID, 1, map[string]interface{}{})
+		assert.Nil(t, err)
+		assert.Equal(t, stored, result)
+	})
+
+	t.Run("Fail - Stale version", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(stored, nil)
+		_, err := service.PatchItem(ctx, defaultID, 0, map[string]interface{}{})
+		assert.True(t, errors.Is(err, ErrVersionMismatch))
+	})
+
+	t.Run("Fail - Not found", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(nil, ErrNotFound)
+		_, err := service.PatchItem(ctx, defaultID, 1, map[string]interface{}{"title": "Groceries"})
+		assert.True(t, errors.Is(err, ErrNotFound))
+	})
+
+	t.Run("Fail - Unknown field", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(stored, nil)
+		_, err := service.PatchItem(ctx, defaultID, 1, map[string]interface{}{"ID": "other"})
+		assert.True(t, errors.Is(err, ErrValidation))
+	})
+
+	t.Run("Fail - Removing required field", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(stored, nil)
+		_, err := service.PatchItem(ctx, defaultID, 1, map[string]interface{}{"text": nil})
+		assert.True(t, errors.Is(err, ErrValidation))
+	})
+
+	t.Run("Fail - Wrong type", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(stored, nil)
+		_, err := service.PatchItem(ctx, defaultID, 1, map[string]interface{}{"title": 42.0})
+		assert.True(t, errors.Is(err, ErrValidation))
+	})
+
+	t.Run("Fail - Due date without time zone", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(stored, nil)
+		_, err := service.PatchItem(ctx, defaultID, 1, map[string]interface{}{"dueAt": "2021-03-22T18:00:00"})
+		assert.True(t, errors.Is(err, ErrValidation))
+	})
+}
+
+func TestCompleteItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockRepo := newTestService(ctrl)
+
+	t.Run("Success", func(t *testing.T) {
+		completed := &model.Item{ID: defaultID, Done: true}
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(&model.Item{ID: defaultID, Version: 3}, nil)
+		mockRepo.EXPECT().Patch(gomock.Any(), defaultID, int64(3), gomock.Any()).DoAndReturn(func(ctx context.Context, id string, version int64, fields map[string]interface{}) (*model.Item, error) {
+			assert.Equal(t, true, fields["done"])
+			assert.Equal(t, now, fields["completedAt"])
+			assert.Equal(t, now, fields["updatedAt"])
+			return completed, nil
+		})
+		mockRepo.EXPECT().List(gomock.Any(), model.ListQuery{Filter: model.ItemFilter{BlockedBy: defaultID}, Limit: MaxPageSize}).Return(&model.Page{}, nil)
+		result, err := service.CompleteItem(ctx, defaultID)
+		assert.Nil(t, err)
+		assert.Equal(t, completed, result)
+	})
+
+	t.Run("Fail - Changed concurrently", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(&model.Item{ID: defaultID, Version: 3}, nil)
+		mockRepo.EXPECT().Patch(gomock.Any(), defaultID, int64(3), gomock.Any()).Return(nil, ErrVersionMismatch)
+		_, err := service.CompleteItem(ctx, defaultID)
+		assert.True(t, errors.Is(err, ErrConflict))
+	})
+
+	t.Run("Fail - Already completed", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(&model.Item{ID: defaultID, Done: true}, nil)
+		mockRepo.EXPECT().List(gomock.Any(), model.ListQuery{Filter: model.ItemFilter{BlockedBy: defaultID}, Limit: MaxPageSize}).Return(&model.Page{}, nil)
+		_, err := service.CompleteItem(ctx, defaultID)
+		assert.True(t, errors.Is(err, ErrConflict))
+	})
+
+	t.Run("Fail - Not found", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(nil, ErrNotFound)
+		_, err := service.CompleteItem(ctx, defaultID)
+		assert.True(t, errors.Is(err, ErrNotFound))
+	})
+}
+
+func TestReopenItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockRepo := newTestService(ctrl)
+
+	t.Run("Success", func(t *testing.T) {
+		reopened := &model.Item{ID: defaultID}
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(&model.Item{ID: defaultID, Done: true}, nil)
+		mockRepo.EXPECT().Patch(gomock.Any(), defaultID, int64(0), map[string]interface{}{"done": false, "completedAt": nil, "updatedAt": now}).Return(reopened, nil)
+		result, err := service.ReopenItem(ctx, defaultID)
+		assert.Nil(t, err)
+		assert.Equal(t, reopened, result)
+	})
+
+	t.Run("Fail - Not completed", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), defaultID).Return(&model.Item{ID: defaultID}, nil)
+		_, err := service.ReopenItem(ctx, defaultID)
+		assert.True(t, errors.Is(err, ErrConflict))
+	})
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+	"github.com/google/uuid"
+	bolt "go.etcd.io/bbolt"
+)
+
+func (repo *boltRepo) SaveList(ctx context.Context, list *model.List) (*model.List, error) {
+	stored := cloneList(list)
+	stored.ID = uuid.NewString()
+	stored.Version = 1
+	err := repo.db.Update(func(tx *bolt.Tx) error {
+		return putList(tx, stored)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+func (repo *boltRepo) FindListByID(ctx context.Context, id string) (*model.List, error) {
+	var list *model.List
+	err := repo.db.View(func(tx *bolt.Tx) error {
+		var err error
+		list, err = getList(tx, id)
+		return err
+	})
+	return list, err
+}
+
+func (repo *boltRepo) AllLists(ctx context.Context) ([]*model.List, error) {
+	lists := make([]*model.List, 0)
+	err := repo.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(listsBucket).ForEach(func(key, value []byte) error {
+			list := &model.List{}
+			if err := json.Unmarshal(value, list); err != nil {
+				return err
+			}
+			lists = append(lists, list)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortLists(lists)
+	return lists, nil
+}
+
+func (repo *boltRepo) UpdateList(ctx context.Context, list *model.List) (*model.List, error) {
+	stored := cloneList(list)
+	stored.Version++
+	err := repo.db.Update(func(tx *bolt.Tx) error {
+		current, err := getList(tx, list.ID)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(current.ID, current.Version, list.Version); err != nil {
+			return err
+		}
+		return putList(tx, stored)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+func (repo *boltRepo) DeleteListByID(ctx context.Context, id string, version int64) error {
+	return repo.db.Update(func(tx *bolt.Tx) error {
+		current, err := getList(tx, id)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(current.ID, current.Version, version); err != nil {
+			return err
+		}
+		return tx.Bucket(listsBucket).Delete([]byte(id))
+	})
+}
+
+func getList(tx *bolt.Tx, id string) (*model.List, error) {
+	value := tx.Bucket(listsBucket).Get([]byte(id))
+	if value == nil {
+		return nil, fmt.Errorf("%w: list %s", model.ErrNotFound, id)
+	}
+	list := &model.List{}
+	return list, json.Unmarshal(value, list)
+}
+
+func putList(tx *bolt.Tx, list *model.List) error {
+	value, err := json.Marshal(list)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(listsBucket).Put([]byte(list.ID), value)
+}
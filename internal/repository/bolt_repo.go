@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+	"github.com/google/uuid"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	itemsBucket = []byte("items")
+	listsBucket = []byte("lists")
+)
+
+type boltRepo struct {
+	db     *bolt.DB
+	cursor cursorSigner
+}
+
+func NewBolt(path string) (*boltRepo, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{itemsBucket, listsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &boltRepo{
+		db:     db,
+		cursor: newCursorSigner(),
+	}, nil
+}
+
+func (repo *boltRepo) Close() error {
+	return repo.db.Close()
+}
+
+func (repo *boltRepo) Save(ctx context.Context, item *model.Item) (*model.Item, error) {
+	stored := cloneItem(item)
+	stored.ID = uuid.NewString()
+	stored.Version = 1
+	err := repo.db.Update(func(tx *bolt.Tx) error {
+		return putItem(tx, stored)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+func (repo *boltRepo) FindByID(ctx context.Context, id string) (*model.Item, error) {
+	var item *model.Item
+	err := repo.db.View(func(tx *bolt.Tx) error {
+		var err error
+		item, err = getItem(tx, id)
+		return err
+	})
+	return item, err
+}
+
+func (repo *boltRepo) List(ctx context.Context, query model.ListQuery) (*model.Page, error) {
+	items, err := repo.allItems()
+	if err != nil {
+		return nil, err
+	}
+	return listItems(items, query, repo.cursor)
+}
+
+func (repo *boltRepo) CountTags(ctx context.Context) ([]model.TagCount, error) {
+	items, err := repo.allItems()
+	if err != nil {
+		return nil, err
+	}
+	return countTags(items), nil
+}
+
+func (repo *boltRepo) allItems() ([]*model.Item, error) {
+	items := make([]*model.Item, 0)
+	err := repo.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(itemsBucket).ForEach(func(key, value []byte) error {
+			item := &model.Item{}
+			if err := json.Unmarshal(value, item); err != nil {
+				return err
+			}
+			items = append(items, item)
+			return nil
+		})
+	})
+	return items, err
+}
+
+func (repo *boltRepo) Update(ctx context.Context, item *model.Item) (*model.Item, error) {
+	stored := cloneItem(item)
+	stored.Version++
+	err := repo.db.Update(func(tx *bolt.Tx) error {
+		current, err := getItem(tx, item.ID)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(current.ID, current.Version, item.Version); err != nil {
+			return err
+		}
+		return putItem(tx, stored)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+func (repo *boltRepo) Patch(ctx context.Context, id string, version int64, fields map[string]interface{}) (*model.Item, error) {
+	var patched *model.Item
+	err := repo.db.Update(func(tx *bolt.Tx) error {
+		current, err := getItem(tx, id)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(current.ID, current.Version, version); err != nil {
+			return err
+		}
+		if patched, err = applyFields(current, fields); err != nil {
+			return err
+		}
+		patched.Version++
+		return putItem(tx, patched)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return patched, nil
+}
+
+func (repo *boltRepo) DeleteByID(ctx context.Context, id string, version int64) error {
+	return repo.db.Update(func(tx *bolt.Tx) error {
+		current, err := getItem(tx, id)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(current.ID, current.Version, version); err != nil {
+			return err
+		}
+		return tx.Bucket(itemsBucket).Delete([]byte(id))
+	})
+}
+
+func getItem(tx *bolt.Tx, id string) (*model.Item, error) {
+	value := tx.Bucket(itemsBucket).Get([]byte(id))
+	if value == nil {
+		return nil, fmt.Errorf("%w: %s", model.ErrNotFound, id)
+	}
+	item := &model.Item{}
+	return item, json.Unmarshal(value, item)
+}
+
+func putItem(tx *bolt.Tx, item *model.Item) error {
+	value, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(itemsBucket).Put([]byte(item.ID), value)
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+)
+
+func TestBoltRepo(t *testing.T) {
+	ctx := context.TODO()
+	dir, err := ioutil.TempDir("", "todo-bolt")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "todo.db")
+
+	repo, err := NewBolt(path)
+	assert.Nil(t, err)
+
+	saved, err := repo.Save(ctx, &model.Item{Title: "List", Text: "Homework"})
+	assert.Nil(t, err)
+	deleted, _ := repo.Save(ctx, &model.Item{Title: "Trash", Text: "Out"})
+
+	t.Run("Patch", func(t *testing.T) {
+		patched, err := repo.Patch(ctx, saved.ID, saved.Version, map[string]interface{}{"done": true})
+		assert.Nil(t, err)
+		assert.True(t, patched.Done)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		assert.Nil(t, repo.DeleteByID(ctx, deleted.ID, deleted.Version))
+		assert.True(t, errors.Is(repo.DeleteByID(ctx, deleted.ID, deleted.Version), model.ErrNotFound))
+	})
+
+	t.Run("Survives a restart", func(t *testing.T) {
+		assert.Nil(t, repo.Close())
+		reopened, err := NewBolt(path)
+		assert.Nil(t, err)
+		defer reopened.Close()
+
+		found, err := reopened.FindByID(ctx, saved.ID)
+		assert.Nil(t, err)
+		assert.Equal(t, &model.Item{ID: saved.ID, Title: "List", Text: "Homework", Done: true, Version: 2}, found)
+
+		page, err := reopened.List(ctx, model.ListQuery{Filter: model.ItemFilter{Status: model.StatusDone}, Limit: 10})
+		assert.Nil(t, err)
+		assert.Len(t, page.Items, 1)
+
+		_, err = reopened.FindByID(ctx, deleted.ID)
+		assert.True(t, errors.Is(err, model.ErrNotFound))
+	})
+}
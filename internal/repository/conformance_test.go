@@ -0,0 +1,137 @@
+package repository_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/config"
+	"github.com/BrunoDM2943/go-todo-lambda/internal/repository"
+	"github.com/BrunoDM2943/go-todo-lambda/internal/repository/repotest"
+)
+
+func TestInMemoryConformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repository.TodoRepository {
+		return repository.NewInMemory()
+	})
+}
+
+func TestBoltConformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repository.TodoRepository {
+		repo, err := repository.NewBolt(filepath.Join(t.TempDir(), "todo.db"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { repo.Close() })
+		return repo
+	})
+}
+
+func TestPostgresConformance(t *testing.T) {
+	if os.Getenv("TODO_POSTGRES_DSN") == "" {
+		t.Skip("TODO_POSTGRES_DSN is not set")
+	}
+	repotest.Run(t, func(t *testing.T) repository.TodoRepository {
+		repo := newTestPostgres(t)
+		t.Cleanup(func() { repo.Close() })
+		return repo
+	})
+}
+
+// TestDynamoDBConformance runs against DynamoDB Local, e.g.
+// `docker run -p 8000:8000 amazon/dynamodb-local` with
+// TODO_DYNAMODB_ENDPOINT=http://localhost:8000. It recreates its own table for
+// every subtest, so never point it at a real account.
+func TestDynamoDBConformance(t *testing.T) {
+	endpoint := os.Getenv("TODO_DYNAMODB_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("TODO_DYNAMODB_ENDPOINT is not set")
+	}
+	for key, value := range map[string]string{
+		"AWS_ACCESS_KEY_ID":     "local",
+		"AWS_SECRET_ACCESS_KEY": "local",
+	} {
+		if os.Getenv(key) == "" {
+			os.Setenv(key, value)
+		}
+	}
+	settings := config.DynamoDB{Table: "todo-conformance", ListsTable: "todo-conformance-lists", Endpoint: endpoint, Region: "us-east-1"}
+	client := dynamodb.New(session.Must(session.NewSession(&aws.Config{
+		Endpoint: aws.String(settings.Endpoint),
+		Region:   aws.String(settings.Region),
+	})))
+
+	repotest.Run(t, func(t *testing.T) repository.TodoRepository {
+		resetTable(t, client, itemsTable(settings.Table))
+		resetTable(t, client, listsTable(settings.ListsTable))
+		return repository.NewDynamoDB(settings)
+	})
+}
+
+func resetTable(t *testing.T, client *dynamodb.DynamoDB, input *dynamodb.CreateTableInput) {
+	ctx := context.TODO()
+	table := input.TableName
+	_, err := client.DeleteTableWithContext(ctx, &dynamodb.DeleteTableInput{TableName: table})
+	if aerr, ok := err.(awserr.Error); err != nil && !(ok && aerr.Code() == dynamodb.ErrCodeResourceNotFoundException) {
+		t.Fatal(err)
+	}
+	if _, err = client.CreateTableWithContext(ctx, input); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.WaitUntilTableExistsWithContext(ctx, &dynamodb.DescribeTableInput{TableName: table}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func itemsTable(name string) *dynamodb.CreateTableInput {
+	return &dynamodb.CreateTableInput{
+		TableName: aws.String(name),
+		AttributeDefinitions: []*dynamodb.AttributeDefinition{
+			{AttributeName: aws.String("ID"), AttributeType: aws.String(dynamodb.ScalarAttributeTypeS)},
+			{AttributeName: aws.String("dueKey"), AttributeType: aws.String(dynamodb.ScalarAttributeTypeS)},
+			{AttributeName: aws.String("dueAt"), AttributeType: aws.String(dynamodb.ScalarAttributeTypeS)},
+			{AttributeName: aws.String("listId"), AttributeType: aws.String(dynamodb.ScalarAttributeTypeS)},
+		},
+		KeySchema: []*dynamodb.KeySchemaElement{
+			{AttributeName: aws.String("ID"), KeyType: aws.String(dynamodb.KeyTypeHash)},
+		},
+		GlobalSecondaryIndexes: []*dynamodb.GlobalSecondaryIndex{
+			{
+				IndexName: aws.String("due-index"),
+				KeySchema: []*dynamodb.KeySchemaElement{
+					{AttributeName: aws.String("dueKey"), KeyType: aws.String(dynamodb.KeyTypeHash)},
+					{AttributeName: aws.String("dueAt"), KeyType: aws.String(dynamodb.KeyTypeRange)},
+				},
+				Projection: &dynamodb.Projection{ProjectionType: aws.String(dynamodb.ProjectionTypeAll)},
+			},
+			{
+				IndexName: aws.String("list-index"),
+				KeySchema: []*dynamodb.KeySchemaElement{
+					{AttributeName: aws.String("listId"), KeyType: aws.String(dynamodb.KeyTypeHash)},
+					{AttributeName: aws.String("ID"), KeyType: aws.String(dynamodb.KeyTypeRange)},
+				},
+				Projection: &dynamodb.Projection{ProjectionType: aws.String(dynamodb.ProjectionTypeAll)},
+			},
+		},
+		BillingMode: aws.String(dynamodb.BillingModePayPerRequest),
+	}
+}
+
+func listsTable(name string) *dynamodb.CreateTableInput {
+	return &dynamodb.CreateTableInput{
+		TableName: aws.String(name),
+		AttributeDefinitions: []*dynamodb.AttributeDefinition{
+			{AttributeName: aws.String("ID"), AttributeType: aws.String(dynamodb.ScalarAttributeTypeS)},
+		},
+		KeySchema: []*dynamodb.KeySchemaElement{
+			{AttributeName: aws.String("ID"), KeyType: aws.String(dynamodb.KeyTypeHash)},
+		},
+		BillingMode: aws.String(dynamodb.BillingModePayPerRequest),
+	}
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+)
+
+const cursorSecretEnv = "CURSOR_SECRET"
+
+// cursorPosition is where a listing stopped. Key is used when the backend
+// pages in its natural order, Offset when results had to be sorted first.
+// Query pins the cursor to the filter and sort it was issued for.
+type cursorPosition struct {
+	Query  string                 `json:"q"`
+	Key    map[string]interface{} `json:"k,omitempty"`
+	Offset int                    `json:"o,omitempty"`
+}
+
+func queryFingerprint(query model.ListQuery) string {
+	raw, _ := json.Marshal(struct {
+		Filter model.ItemFilter
+		Sort   model.SortOrder
+	}{query.Filter, query.Sort})
+	sum := sha256.Sum256(raw)
+	return base64.RawURLEncoding.EncodeToString(sum[:8])
+}
+
+// cursorSigner turns repository positions into opaque tokens. Tokens are
+// signed so clients cannot forge a position they were never handed.
+type cursorSigner struct {
+	secret []byte
+}
+
+func newCursorSigner() cursorSigner {
+	if secret := os.Getenv(cursorSecretEnv); secret != "" {
+		return cursorSigner{secret: []byte(secret)}
+	}
+	log.Printf("%s is not set, cursors will only be valid for this instance", cursorSecretEnv)
+	secret := make([]byte, 32)
+	_, _ = rand.Read(secret)
+	return cursorSigner{secret: secret}
+}
+
+func (signer cursorSigner) encode(position interface{}) (string, error) {
+	payload, err := json.Marshal(position)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(signer.sign(payload)), nil
+}
+
+func (signer cursorSigner) decode(cursor string, position interface{}) error {
+	parts := strings.SplitN(cursor, ".", 2)
+	if len(parts) != 2 {
+		return invalidCursor()
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return invalidCursor()
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, signer.sign(payload)) {
+		return invalidCursor()
+	}
+	if err := json.Unmarshal(payload, position); err != nil {
+		return invalidCursor()
+	}
+	return nil
+}
+
+func (signer cursorSigner) encodePosition(query model.ListQuery, position cursorPosition) (string, error) {
+	position.Query = queryFingerprint(query)
+	return signer.encode(position)
+}
+
+func (signer cursorSigner) decodePosition(query model.ListQuery) (cursorPosition, error) {
+	position := cursorPosition{}
+	if query.Cursor == "" {
+		return position, nil
+	}
+	if err := signer.decode(query.Cursor, &position); err != nil {
+		return position, err
+	}
+	if position.Query != queryFingerprint(query) || position.Offset < 0 {
+		return position, invalidCursor()
+	}
+	return position, nil
+}
+
+func (signer cursorSigner) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, signer.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func invalidCursor() error {
+	return fmt.Errorf("%w: invalid cursor", model.ErrValidation)
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+)
+
+func TestCursorSigner(t *testing.T) {
+	signer := cursorSigner{secret: []byte("secret")}
+
+	t.Run("Round trip", func(t *testing.T) {
+		cursor, err := signer.encode(map[string]interface{}{"ID": "XPTO"})
+		assert.Nil(t, err)
+
+		position := map[string]interface{}{}
+		assert.Nil(t, signer.decode(cursor, &position))
+		assert.Equal(t, "XPTO", position["ID"])
+	})
+
+	t.Run("Tampered payload", func(t *testing.T) {
+		cursor, _ := signer.encode(map[string]interface{}{"ID": "XPTO"})
+		other, _ := signer.encode(map[string]interface{}{"ID": "OTHER"})
+		forged := strings.Split(other, ".")[0] + "." + strings.Split(cursor, ".")[1]
+
+		position := map[string]interface{}{}
+		assert.True(t, errors.Is(signer.decode(forged, &position), model.ErrValidation))
+	})
+
+	t.Run("Different secret", func(t *testing.T) {
+		cursor, _ := cursorSigner{secret: []byte("other")}.encode(map[string]interface{}{"ID": "XPTO"})
+
+		position := map[string]interface{}{}
+		assert.True(t, errors.Is(signer.decode(cursor, &position), model.ErrValidation))
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		position := map[string]interface{}{}
+		assert.True(t, errors.Is(signer.decode("not-a-cursor", &position), model.ErrValidation))
+	})
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+)
+
+// Items with a due date are also kept in a sparse global secondary index,
+// keyed by a constant partition and sorted by due date, so the overdue and
+// upcoming views are a single range query instead of a table scan. Due dates
+// are stored in a fixed-width UTC layout to make string order time order.
+const (
+	dueIndex     = "due-index"
+	dueKeyName   = "dueKey"
+	dueKeyValue  = "due"
+	dueAttribute = "dueAt"
+	dueLayout    = "2006-01-02T15:04:05Z"
+)
+
+func formatDue(due time.Time) string {
+	return due.UTC().Format(dueLayout)
+}
+
+// withDueAttributes indexes a marshalled item by its due date, if it has one.
+func withDueAttributes(marshalled map[string]*dynamodb.AttributeValue, item *model.Item) map[string]*dynamodb.AttributeValue {
+	if item.DueAt != nil {
+		marshalled[dueKeyName] = &dynamodb.AttributeValue{S: aws.String(dueKeyValue)}
+		marshalled[dueAttribute] = &dynamodb.AttributeValue{S: aws.String(formatDue(*item.DueAt))}
+	}
+	return marshalled
+}
+
+// patchDue sets or clears the due date together with its index key.
+func patchDue(update expression.UpdateBuilder, value interface{}) (expression.UpdateBuilder, error) {
+	if value == nil {
+		return update.Remove(expression.Name(dueAttribute)).Remove(expression.Name(dueKeyName)), nil
+	}
+	var due time.Time
+	switch typed := value.(type) {
+	case time.Time:
+		due = typed
+	case *time.Time:
+		due = *typed
+	case string:
+		parsed, err := time.Parse(time.RFC3339, typed)
+		if err != nil {
+			return update, fmt.Errorf("%w: dueAt must be an RFC 3339 timestamp", model.ErrValidation)
+		}
+		due = parsed
+	default:
+		return update, fmt.Errorf("%w: dueAt must be an RFC 3339 timestamp", model.ErrValidation)
+	}
+	return update.
+		Set(expression.Name(dueAttribute), expression.Value(formatDue(due))).
+		Set(expression.Name(dueKeyName), expression.Value(dueKeyValue)), nil
+}
+
+// dueKeyCondition turns the due bounds of a filter into a range on the index.
+// Stored due dates have whole seconds, so bounds are rounded up to the next
+// second before they are compared.
+func dueKeyCondition(filter model.ItemFilter) expression.KeyConditionBuilder {
+	partition := expression.Key(dueKeyName).Equal(expression.Value(dueKeyValue))
+	due := expression.Key(dueAttribute)
+	switch {
+	case filter.DueAfter != nil && filter.DueBefore != nil:
+		lower := formatDue(ceilSecond(*filter.DueAfter))
+		upper := formatDue(ceilSecond(*filter.DueBefore).Add(-time.Second))
+		return partition.And(due.Between(expression.Value(lower), expression.Value(upper)))
+	case filter.DueAfter != nil:
+		return partition.And(due.GreaterThanEqual(expression.Value(formatDue(ceilSecond(*filter.DueAfter)))))
+	case filter.DueBefore != nil:
+		return partition.And(due.LessThan(expression.Value(formatDue(ceilSecond(*filter.DueBefore)))))
+	}
+	return partition
+}
+
+func ceilSecond(instant time.Time) time.Time {
+	truncated := instant.Truncate(time.Second)
+	if truncated.Before(instant) {
+		return truncated.Add(time.Second)
+	}
+	return truncated
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+)
+
+func buildFilterCondition(filter model.ItemFilter) (expression.ConditionBuilder, bool) {
+	conditions := make([]expression.ConditionBuilder, 0)
+
+	switch filter.Status {
+	case model.StatusDone:
+		conditions = append(conditions, expression.Name("done").Equal(expression.Value(true)))
+	case model.StatusOpen:
+		conditions = append(conditions, expression.Or(
+			expression.AttributeNotExists(expression.Name("done")),
+			expression.Name("done").Equal(expression.Value(false)),
+		))
+	}
+	if filter.Query != "" {
+		conditions = append(conditions, expression.Or(
+			expression.Name("title").Contains(filter.Query),
+			expression.Name("text").Contains(filter.Query),
+		))
+	}
+
+	if len(filter.Tags) > 0 {
+		tagConditions := make([]expression.ConditionBuilder, 0, len(filter.Tags))
+		for _, tag := range filter.Tags {
+			tagConditions = append(tagConditions, expression.Name("tags").Contains(tag))
+		}
+		if filter.TagMatch == model.TagMatchAny {
+			conditions = append(conditions, combine(expression.Or, tagConditions))
+		} else {
+			conditions = append(conditions, tagConditions...)
+		}
+	}
+
+	if filter.ListID != "" {
+		conditions = append(conditions, expression.Name(listAttribute).Equal(expression.Value(filter.ListID)))
+	}
+	if filter.Archived != nil {
+		if *filter.Archived {
+			conditions = append(conditions, expression.Name("archived").Equal(expression.Value(true)))
+		} else {
+			conditions = append(conditions, expression.Or(
+				expression.AttributeNotExists(expression.Name("archived")),
+				expression.Name("archived").Equal(expression.Value(false)),
+			))
+		}
+	}
+	if filter.Blocked != nil {
+		if *filter.Blocked {
+			conditions = append(conditions, expression.AttributeExists(expression.Name("blockedBy")))
+		} else {
+			conditions = append(conditions, expression.AttributeNotExists(expression.Name("blockedBy")))
+		}
+	}
+	if filter.BlockedBy != "" {
+		conditions = append(conditions, expression.Name("blockedBy").Contains(filter.BlockedBy))
+	}
+	if filter.DueAfter != nil {
+		conditions = append(conditions, expression.Name(dueAttribute).GreaterThanEqual(expression.Value(formatDue(ceilSecond(*filter.DueAfter)))))
+	}
+	if filter.DueBefore != nil {
+		conditions = append(conditions, expression.Name(dueAttribute).LessThan(expression.Value(formatDue(ceilSecond(*filter.DueBefore)))))
+	}
+
+	if len(conditions) == 0 {
+		return expression.ConditionBuilder{}, false
+	}
+	return combine(expression.And, conditions), true
+}
+
+func combine(operator func(left, right expression.ConditionBuilder, other ...expression.ConditionBuilder) expression.ConditionBuilder, conditions []expression.ConditionBuilder) expression.ConditionBuilder {
+	if len(conditions) == 1 {
+		return conditions[0]
+	}
+	return operator(conditions[0], conditions[1], conditions[2:]...)
+}
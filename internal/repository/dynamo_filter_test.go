@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+)
+
+func TestBuildFilterCondition(t *testing.T) {
+	t.Run("No filter", func(t *testing.T) {
+		_, ok := buildFilterCondition(model.ItemFilter{})
+		assert.False(t, ok)
+	})
+
+	t.Run("Status and query", func(t *testing.T) {
+		condition, ok := buildFilterCondition(model.ItemFilter{Status: model.StatusOpen, Query: "home"})
+		assert.True(t, ok)
+
+		expr, err := expression.NewBuilder().WithFilter(condition).Build()
+		assert.Nil(t, err)
+		assert.Equal(t, "((attribute_not_exists (#0)) OR (#0 = :0)) AND ((contains (#1, :1)) OR (contains (#2, :2)))", *expr.Filter())
+	})
+
+	t.Run("List and archive", func(t *testing.T) {
+		archived := false
+		condition, ok := buildFilterCondition(model.ItemFilter{ListID: "work", Archived: &archived})
+		assert.True(t, ok)
+
+		expr, err := expression.NewBuilder().WithFilter(condition).Build()
+		assert.Nil(t, err)
+		assert.Equal(t, "(#0 = :0) AND ((attribute_not_exists (#1)) OR (#1 = :1))", *expr.Filter())
+		assert.Equal(t, "archived", *expr.Names()["#1"])
+	})
+
+	t.Run("Blocked", func(t *testing.T) {
+		blocked := true
+		condition, ok := buildFilterCondition(model.ItemFilter{Blocked: &blocked, BlockedBy: "draft"})
+		assert.True(t, ok)
+
+		expr, err := expression.NewBuilder().WithFilter(condition).Build()
+		assert.Nil(t, err)
+		assert.Equal(t, "(attribute_exists (#0)) AND (contains (#0, :0))", *expr.Filter())
+		assert.Equal(t, "blockedBy", *expr.Names()["#0"])
+	})
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/google/uuid"
+)
+
+// Lists live in a table of their own. Items that belong to a list are also
+// kept in a sparse index keyed by list, so listing one list is a query.
+const (
+	listIndex     = "list-index"
+	listAttribute = "listId"
+)
+
+func (repo *dynamoDBRepo) SaveList(ctx context.Context, list *model.List) (*model.List, error) {
+	stored := cloneList(list)
+	stored.ID = uuid.NewString()
+	stored.Version = 1
+	marshalled, _ := dynamodbattribute.MarshalMap(stored)
+	_, err := repo.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		Item:      marshalled,
+		TableName: aws.String(repo.listsTable),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+func (repo *dynamoDBRepo) FindListByID(ctx context.Context, id string) (*model.List, error) {
+	result, err := repo.client.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(repo.listsTable),
+		Key: map[string]*dynamodb.AttributeValue{
+			"ID": {
+				S: aws.String(id),
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Item == nil {
+		return nil, fmt.Errorf("%w: list %s", model.ErrNotFound, id)
+	}
+	list := &model.List{}
+	return list, dynamodbattribute.UnmarshalMap(result.Item, list)
+}
+
+func (repo *dynamoDBRepo) AllLists(ctx context.Context) ([]*model.List, error) {
+	lists := make([]*model.List, 0)
+	var unmarshalErr error
+	err := repo.client.ScanPagesWithContext(ctx, &dynamodb.ScanInput{
+		TableName: aws.String(repo.listsTable),
+	}, func(result *dynamodb.ScanOutput, lastPage bool) bool {
+		page := make([]*model.List, 0, len(result.Items))
+		if unmarshalErr = dynamodbattribute.UnmarshalListOfMaps(result.Items, &page); unmarshalErr != nil {
+			return false
+		}
+		lists = append(lists, page...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+	sortLists(lists)
+	return lists, nil
+}
+
+func (repo *dynamoDBRepo) UpdateList(ctx context.Context, list *model.List) (*model.List, error) {
+	stored := cloneList(list)
+	stored.Version++
+	marshalled, _ := dynamodbattribute.MarshalMap(stored)
+	expr, err := expression.NewBuilder().
+		WithCondition(versionCondition(list.Version)).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	_, err = repo.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		Item:                      marshalled,
+		TableName:                 aws.String(repo.listsTable),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if isConditionalCheckFailed(err) {
+		return nil, repo.explainListConditionFailure(ctx, list.ID)
+	} else if err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+func (repo *dynamoDBRepo) DeleteListByID(ctx context.Context, id string, version int64) error {
+	expr, err := expression.NewBuilder().
+		WithCondition(versionCondition(version)).
+		Build()
+	if err != nil {
+		return err
+	}
+	_, err = repo.client.DeleteItemWithContext(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(repo.listsTable),
+		Key: map[string]*dynamodb.AttributeValue{
+			"ID": {
+				S: aws.String(id),
+			},
+		},
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if isConditionalCheckFailed(err) {
+		return repo.explainListConditionFailure(ctx, id)
+	}
+	return err
+}
+
+func (repo *dynamoDBRepo) explainListConditionFailure(ctx context.Context, id string) error {
+	current, err := repo.FindListByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: %s is at version %d", model.ErrVersionMismatch, id, current.Version)
+}
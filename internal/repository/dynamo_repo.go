@@ -1,43 +1,68 @@
 package repository
 
 import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/config"
 	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
 	"github.com/google/uuid"
 )
 
-const TableName = "todo"
-
 type dynamoDBRepo struct {
-	client *dynamodb.DynamoDB
+	client     *dynamodb.DynamoDB
+	table      string
+	listsTable string
+	cursor     cursorSigner
 }
 
-func NewDynamoDB() TodoRepository {
+func NewDynamoDB(settings config.DynamoDB) TodoRepository {
+	awsConfig := aws.Config{}
+	if settings.Endpoint != "" {
+		awsConfig.Endpoint = aws.String(settings.Endpoint)
+	}
+	if settings.Region != "" {
+		awsConfig.Region = aws.String(settings.Region)
+	}
 	sess := session.Must(session.NewSessionWithOptions(session.Options{
+		Config:            awsConfig,
 		SharedConfigState: session.SharedConfigEnable,
 	}))
 	client := dynamodb.New(sess)
 
 	return &dynamoDBRepo{
-		client: client,
+		client:     client,
+		table:      settings.Table,
+		listsTable: settings.ListsTable,
+		cursor:     newCursorSigner(),
 	}
 }
 
-func (repo *dynamoDBRepo) Save(item *model.Item) error {
-	item.ID = uuid.NewString()
-	marshalled, _ := dynamodbattribute.MarshalMap(item)
-	_, err := repo.client.PutItem(&dynamodb.PutItemInput{
+func (repo *dynamoDBRepo) Save(ctx context.Context, item *model.Item) (*model.Item, error) {
+	stored := *item
+	stored.ID = uuid.NewString()
+	stored.Version = 1
+	marshalled, _ := dynamodbattribute.MarshalMap(stored)
+	marshalled = withDueAttributes(marshalled, &stored)
+	_, err := repo.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
 		Item:      marshalled,
-		TableName: aws.String(TableName),
+		TableName: aws.String(repo.table),
 	})
-	return err
+	if err != nil {
+		return nil, err
+	}
+	return &stored, nil
 }
-func (repo *dynamoDBRepo) FindByID(id string) (*model.Item, error) {
-	result, err := repo.client.GetItem(&dynamodb.GetItemInput{
-		TableName: aws.String(TableName),
+func (repo *dynamoDBRepo) FindByID(ctx context.Context, id string) (*model.Item, error) {
+	result, err := repo.client.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(repo.table),
 		Key: map[string]*dynamodb.AttributeValue{
 			"ID": {
 				S: aws.String(id),
@@ -48,42 +73,352 @@ func (repo *dynamoDBRepo) FindByID(id string) (*model.Item, error) {
 		return nil, err
 	}
 	if result.Item == nil {
-		return nil, nil
+		return nil, fmt.Errorf("%w: %s", model.ErrNotFound, id)
 	}
-	item := &model.Item{}
-	dynamodbattribute.UnmarshalMap(result.Item, item)
-	return item, nil
+	return unmarshalItem(result.Item), nil
+}
+func (repo *dynamoDBRepo) List(ctx context.Context, query model.ListQuery) (*model.Page, error) {
+	position, err := repo.cursor.decodePosition(query)
+	if err != nil {
+		return nil, err
+	}
+	if query.Filter.ListID != "" {
+		return repo.listInList(ctx, query, position)
+	}
+	if query.Filter.DueAfter != nil || query.Filter.DueBefore != nil {
+		return repo.listDue(ctx, query, position)
+	}
+	input := &dynamodb.ScanInput{
+		TableName: aws.String(repo.table),
+	}
+	if condition, ok := buildFilterCondition(query.Filter); ok {
+		expr, err := expression.NewBuilder().WithFilter(condition).Build()
+		if err != nil {
+			return nil, err
+		}
+		input.FilterExpression = expr.Filter()
+		input.ExpressionAttributeNames = expr.Names()
+		input.ExpressionAttributeValues = expr.Values()
+	}
+	scan := func(startKey attributeMap, limit int64) ([]attributeMap, attributeMap, error) {
+		input.ExclusiveStartKey = startKey
+		input.Limit = nil
+		if limit > 0 {
+			input.Limit = aws.Int64(limit)
+		}
+		result, err := repo.client.ScanWithContext(ctx, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return result.Items, result.LastEvaluatedKey, nil
+	}
+	if query.Sort.Field != "" {
+		return repo.listSorted(query, position, scan)
+	}
+	return repo.listInKeyOrder(query, position, scan)
 }
-func (repo *dynamoDBRepo) ListAll() ([]*model.Item, error) {
 
-	result, err := repo.client.Scan(&dynamodb.ScanInput{
-		TableName: aws.String(TableName),
-	})
+// listInList answers queries for one list from the list index. Due bounds
+// and every other criterion are filters on top of it.
+func (repo *dynamoDBRepo) listInList(ctx context.Context, query model.ListQuery, position cursorPosition) (*model.Page, error) {
+	key := expression.Key(listAttribute).Equal(expression.Value(query.Filter.ListID))
+	rest := query.Filter
+	rest.ListID = ""
+	return repo.listFromIndex(ctx, listIndex, key, rest, query, position, "")
+}
+
+// listDue answers queries bounded by due date from the due index, which
+// already returns items in due date order.
+func (repo *dynamoDBRepo) listDue(ctx context.Context, query model.ListQuery, position cursorPosition) (*model.Page, error) {
+	filter := query.Filter
+	if filter.DueAfter != nil && filter.DueBefore != nil && !ceilSecond(*filter.DueBefore).After(ceilSecond(*filter.DueAfter)) {
+		return &model.Page{Items: make([]*model.Item, 0)}, nil
+	}
+	rest := filter
+	rest.DueAfter, rest.DueBefore = nil, nil
+	return repo.listFromIndex(ctx, dueIndex, dueKeyCondition(filter), rest, query, position, model.SortByDueDate)
+}
+
+// listFromIndex queries a secondary index. A filter expression may not name
+// the index keys, so rest holds only the criteria the key does not answer.
+// Results stay in index order unless the query sorts by something other than
+// indexOrder.
+func (repo *dynamoDBRepo) listFromIndex(ctx context.Context, index string, key expression.KeyConditionBuilder, rest model.ItemFilter, query model.ListQuery, position cursorPosition, indexOrder string) (*model.Page, error) {
+	builder := expression.NewBuilder().WithKeyCondition(key)
+	if condition, ok := buildFilterCondition(rest); ok {
+		builder = builder.WithFilter(condition)
+	}
+	expr, err := builder.Build()
 	if err != nil {
 		return nil, err
 	}
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(repo.table),
+		IndexName:                 aws.String(index),
+		KeyConditionExpression:    expr.KeyCondition(),
+		FilterExpression:          expr.Filter(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ScanIndexForward:          aws.Bool(query.Sort.Field != indexOrder || !query.Sort.Descending),
+	}
+	fetch := func(startKey attributeMap, limit int64) ([]attributeMap, attributeMap, error) {
+		input.ExclusiveStartKey = startKey
+		input.Limit = nil
+		if limit > 0 {
+			input.Limit = aws.Int64(limit)
+		}
+		result, err := repo.client.QueryWithContext(ctx, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return result.Items, result.LastEvaluatedKey, nil
+	}
+	if query.Sort.Field != "" && query.Sort.Field != indexOrder {
+		return repo.listSorted(query, position, fetch)
+	}
+	return repo.listInKeyOrder(query, position, fetch)
+}
 
-	items := make([]*model.Item, 0)
+type attributeMap = map[string]*dynamodb.AttributeValue
+
+// fetchPage reads up to limit items, or as many as DynamoDB returns in one
+// call when limit is 0, starting after startKey. The returned key is nil once
+// there is nothing left to read.
+type fetchPage func(startKey attributeMap, limit int64) ([]attributeMap, attributeMap, error)
 
-	for _, scannedItem := range result.Items {
-		item := &model.Item{}
-		_ = dynamodbattribute.UnmarshalMap(scannedItem, item)
-		items = append(items, item)
+// listInKeyOrder pages in the order DynamoDB returns items, so the cursor is
+// the last evaluated key.
+func (repo *dynamoDBRepo) listInKeyOrder(query model.ListQuery, position cursorPosition, fetch fetchPage) (*model.Page, error) {
+	var startKey attributeMap
+	if position.Key != nil {
+		marshalled, err := dynamodbattribute.MarshalMap(position.Key)
+		if err != nil {
+			return nil, err
+		}
+		startKey = marshalled
+	}
+
+	page := &model.Page{
+		Items: make([]*model.Item, 0),
+	}
+	for {
+		items, lastKey, err := fetch(startKey, int64(query.Limit-len(page.Items)))
+		if err != nil {
+			return nil, err
+		}
+		page.Items = append(page.Items, unmarshalItems(items)...)
+		startKey = lastKey
+		if startKey == nil || len(page.Items) >= query.Limit {
+			break
+		}
 	}
 
-	return items, nil
+	if startKey != nil {
+		next := cursorPosition{}
+		if err := dynamodbattribute.UnmarshalMap(startKey, &next.Key); err != nil {
+			return nil, err
+		}
+		cursor, err := repo.cursor.encodePosition(query, next)
+		if err != nil {
+			return nil, err
+		}
+		page.NextCursor = cursor
+	}
+	return page, nil
+}
+
+// listSorted has to read every matching item before it can order them, so
+// the cursor is an offset into the sorted result rather than a table key.
+func (repo *dynamoDBRepo) listSorted(query model.ListQuery, position cursorPosition, fetch fetchPage) (*model.Page, error) {
+	items := make([]*model.Item, 0)
+	var startKey attributeMap
+	for {
+		result, lastKey, err := fetch(startKey, 0)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, unmarshalItems(result)...)
+		if startKey = lastKey; startKey == nil {
+			break
+		}
+	}
+	sortItems(items, query.Sort)
+	return pageByOffset(items, query, position, repo.cursor)
+}
+func (repo *dynamoDBRepo) Update(ctx context.Context, item *model.Item) (*model.Item, error) {
+	stored := *item
+	stored.Version++
+	marshalled, _ := dynamodbattribute.MarshalMap(stored)
+	marshalled = withDueAttributes(marshalled, &stored)
+	expr, err := expression.NewBuilder().
+		WithCondition(versionCondition(item.Version)).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	_, err = repo.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		Item:                      marshalled,
+		TableName:                 aws.String(repo.table),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if isConditionalCheckFailed(err) {
+		return nil, repo.explainConditionFailure(ctx, item.ID)
+	} else if err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+func (repo *dynamoDBRepo) Patch(ctx context.Context, id string, version int64, fields map[string]interface{}) (*model.Item, error) {
+	update := expression.Set(expression.Name("version"), expression.Value(version+1))
+	for field, value := range fields {
+		var err error
+		switch field {
+		case "tags", "blockedBy":
+			update, err = patchStringSet(update, field, value)
+		case dueAttribute:
+			update, err = patchDue(update, value)
+		default:
+			if value == nil {
+				update = update.Remove(expression.Name(field))
+			} else {
+				update = update.Set(expression.Name(field), expression.Value(value))
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	expr, err := expression.NewBuilder().
+		WithUpdate(update).
+		WithCondition(versionCondition(version)).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	result, err := repo.client.UpdateItemWithContext(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.table),
+		Key: map[string]*dynamodb.AttributeValue{
+			"ID": {
+				S: aws.String(id),
+			},
+		},
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ReturnValues:              aws.String(dynamodb.ReturnValueAllNew),
+	})
+	if isConditionalCheckFailed(err) {
+		return nil, repo.explainConditionFailure(ctx, id)
+	} else if err != nil {
+		return nil, err
+	}
+	return unmarshalItem(result.Attributes), nil
+}
+func (repo *dynamoDBRepo) CountTags(ctx context.Context) ([]model.TagCount, error) {
+	expr, err := expression.NewBuilder().
+		WithFilter(expression.AttributeExists(expression.Name("tags"))).
+		WithProjection(expression.NamesList(expression.Name("tags"))).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*model.Item, 0)
+	err = repo.client.ScanPagesWithContext(ctx, &dynamodb.ScanInput{
+		TableName:                 aws.String(repo.table),
+		FilterExpression:          expr.Filter(),
+		ProjectionExpression:      expr.Projection(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	}, func(result *dynamodb.ScanOutput, lastPage bool) bool {
+		items = append(items, unmarshalItems(result.Items)...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return countTags(items), nil
 }
-func (repo *dynamoDBRepo) DeleteByID(id string) error {
-	_, err := repo.client.DeleteItem(&dynamodb.DeleteItemInput{
-		TableName: aws.String(TableName),
+
+func (repo *dynamoDBRepo) DeleteByID(ctx context.Context, id string, version int64) error {
+	expr, err := expression.NewBuilder().
+		WithCondition(versionCondition(version)).
+		Build()
+	if err != nil {
+		return err
+	}
+	_, err = repo.client.DeleteItemWithContext(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(repo.table),
 		Key: map[string]*dynamodb.AttributeValue{
 			"ID": {
 				S: aws.String(id),
 			},
 		},
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
 	})
+	if isConditionalCheckFailed(err) {
+		return repo.explainConditionFailure(ctx, id)
+	}
+	return err
+}
+
+// patchStringSet writes tags or dependencies as a string set. DynamoDB has
+// no empty sets, so an empty one removes the attribute.
+func patchStringSet(update expression.UpdateBuilder, field string, value interface{}) (expression.UpdateBuilder, error) {
+	values, ok := value.([]string)
+	if !ok && value != nil {
+		return update, fmt.Errorf("%w: %s must be a list of strings", model.ErrValidation, field)
+	}
+	if len(values) == 0 {
+		return update.Remove(expression.Name(field)), nil
+	}
+	return update.Set(expression.Name(field), expression.Value(&dynamodb.AttributeValue{SS: aws.StringSlice(values)})), nil
+}
+
+// versionCondition only lets a write through when the item exists at the
+// expected version. Items written before versioning have no version
+// attribute and count as version 0.
+func versionCondition(version int64) expression.ConditionBuilder {
+	matches := expression.Name("version").Equal(expression.Value(version))
+	if version == 0 {
+		matches = expression.Or(expression.AttributeNotExists(expression.Name("version")), matches)
+	}
+	return expression.And(expression.AttributeExists(expression.Name("ID")), matches)
+}
+
+// explainConditionFailure tells a missing item from a stale version, which
+// DynamoDB reports the same way.
+func (repo *dynamoDBRepo) explainConditionFailure(ctx context.Context, id string) error {
+	current, err := repo.FindByID(ctx, id)
 	if err != nil {
 		return err
 	}
-	return nil
+	return fmt.Errorf("%w: %s is at version %d", model.ErrVersionMismatch, id, current.Version)
+}
+
+func isConditionalCheckFailed(err error) bool {
+	aerr, ok := err.(awserr.Error)
+	return ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException
+}
+
+func unmarshalItems(attributes []map[string]*dynamodb.AttributeValue) []*model.Item {
+	items := make([]*model.Item, 0, len(attributes))
+	for _, scannedItem := range attributes {
+		items = append(items, unmarshalItem(scannedItem))
+	}
+	return items
+}
+
+// unmarshalItem sorts tags and dependencies again, string sets come back in
+// no particular order.
+func unmarshalItem(attributes map[string]*dynamodb.AttributeValue) *model.Item {
+	item := &model.Item{}
+	_ = dynamodbattribute.UnmarshalMap(attributes, item)
+	sort.Strings(item.Tags)
+	sort.Strings(item.BlockedBy)
+	return item
 }
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionCondition(t *testing.T) {
+	t.Run("Versioned item", func(t *testing.T) {
+		expr, err := expression.NewBuilder().WithCondition(versionCondition(3)).Build()
+		assert.Nil(t, err)
+		assert.Equal(t, "(attribute_exists (#0)) AND (#1 = :0)", *expr.Condition())
+		assert.Equal(t, "3", *expr.Values()[":0"].N)
+	})
+
+	t.Run("Item written before versioning", func(t *testing.T) {
+		expr, err := expression.NewBuilder().WithCondition(versionCondition(0)).Build()
+		assert.Nil(t, err)
+		assert.Equal(t, "(attribute_exists (#0)) AND ((attribute_not_exists (#1)) OR (#1 = :0))", *expr.Condition())
+	})
+}
+
+func TestDueKeyCondition(t *testing.T) {
+	after := time.Date(2021, 3, 20, 10, 0, 0, 0, time.UTC)
+	before := time.Date(2021, 3, 20, 12, 30, 0, 250, time.FixedZone("", -3*60*60))
+
+	t.Run("Window", func(t *testing.T) {
+		expr, err := expression.NewBuilder().WithKeyCondition(dueKeyCondition(model.ItemFilter{DueAfter: &after, DueBefore: &before})).Build()
+		assert.Nil(t, err)
+		assert.Equal(t, "2021-03-20T10:00:00Z", *expr.Values()[":1"].S)
+		assert.Equal(t, "2021-03-20T15:30:00Z", *expr.Values()[":2"].S, "the exclusive bound is rounded up, then made inclusive")
+	})
+
+	t.Run("Before", func(t *testing.T) {
+		expr, err := expression.NewBuilder().WithKeyCondition(dueKeyCondition(model.ItemFilter{DueBefore: &after})).Build()
+		assert.Nil(t, err)
+		assert.Equal(t, "due", *expr.Values()[":0"].S)
+		assert.Equal(t, "2021-03-20T10:00:00Z", *expr.Values()[":1"].S)
+	})
+}
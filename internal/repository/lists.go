@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+)
+
+// ListRepository stores todo lists under the same version contract as
+// TodoRepository. There are few lists, so AllLists reads them at once,
+// ordered by name.
+//
+//go:generate mockgen -source=./lists.go -destination=./mock/lists_mock.go
+type ListRepository interface {
+	SaveList(ctx context.Context, list *model.List) (*model.List, error)
+	FindListByID(ctx context.Context, id string) (*model.List, error)
+	AllLists(ctx context.Context) ([]*model.List, error)
+	UpdateList(ctx context.Context, list *model.List) (*model.List, error)
+	DeleteListByID(ctx context.Context, id string, version int64) error
+}
+
+func sortLists(lists []*model.List) {
+	sort.Slice(lists, func(i, j int) bool {
+		if lists[i].Name != lists[j].Name {
+			return lists[i].Name < lists[j].Name
+		}
+		return lists[i].ID < lists[j].ID
+	})
+}
+
+func cloneList(list *model.List) *model.List {
+	clone := *list
+	clone.CreatedAt = cloneTime(list.CreatedAt)
+	clone.UpdatedAt = cloneTime(list.UpdatedAt)
+	return &clone
+}
+
+func cloneTime(instant *time.Time) *time.Time {
+	if instant == nil {
+		return nil
+	}
+	copied := *instant
+	return &copied
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+	"github.com/google/uuid"
+)
+
+func (repo *inMemoryRepo) SaveList(ctx context.Context, list *model.List) (*model.List, error) {
+	stored := cloneList(list)
+	stored.ID = uuid.NewString()
+	stored.Version = 1
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.lists[stored.ID] = stored
+	return cloneList(stored), nil
+}
+
+func (repo *inMemoryRepo) FindListByID(ctx context.Context, id string) (*model.List, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+	list, ok := repo.lists[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: list %s", model.ErrNotFound, id)
+	}
+	return cloneList(list), nil
+}
+
+func (repo *inMemoryRepo) AllLists(ctx context.Context) ([]*model.List, error) {
+	repo.mutex.RLock()
+	lists := make([]*model.List, 0, len(repo.lists))
+	for _, list := range repo.lists {
+		lists = append(lists, cloneList(list))
+	}
+	repo.mutex.RUnlock()
+	sortLists(lists)
+	return lists, nil
+}
+
+func (repo *inMemoryRepo) UpdateList(ctx context.Context, list *model.List) (*model.List, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	current, ok := repo.lists[list.ID]
+	if !ok {
+		return nil, fmt.Errorf("%w: list %s", model.ErrNotFound, list.ID)
+	}
+	if err := checkVersion(current.ID, current.Version, list.Version); err != nil {
+		return nil, err
+	}
+	stored := cloneList(list)
+	stored.Version++
+	repo.lists[list.ID] = stored
+	return cloneList(stored), nil
+}
+
+func (repo *inMemoryRepo) DeleteListByID(ctx context.Context, id string, version int64) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	current, ok := repo.lists[id]
+	if !ok {
+		return fmt.Errorf("%w: list %s", model.ErrNotFound, id)
+	}
+	if err := checkVersion(current.ID, current.Version, version); err != nil {
+		return err
+	}
+	delete(repo.lists, id)
+	return nil
+}
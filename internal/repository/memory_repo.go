@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+	"github.com/google/uuid"
+)
+
+type inMemoryRepo struct {
+	mutex  sync.RWMutex
+	items  map[string]*model.Item
+	lists  map[string]*model.List
+	cursor cursorSigner
+}
+
+func NewInMemory() TodoRepository {
+	return &inMemoryRepo{
+		items:  map[string]*model.Item{},
+		lists:  map[string]*model.List{},
+		cursor: newCursorSigner(),
+	}
+}
+
+func (repo *inMemoryRepo) Save(ctx context.Context, item *model.Item) (*model.Item, error) {
+	stored := cloneItem(item)
+	stored.ID = uuid.NewString()
+	stored.Version = 1
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.items[stored.ID] = stored
+	return cloneItem(stored), nil
+}
+
+func (repo *inMemoryRepo) FindByID(ctx context.Context, id string) (*model.Item, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+	item, ok := repo.items[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", model.ErrNotFound, id)
+	}
+	return cloneItem(item), nil
+}
+
+func (repo *inMemoryRepo) List(ctx context.Context, query model.ListQuery) (*model.Page, error) {
+	return listItems(repo.snapshot(), query, repo.cursor)
+}
+
+func (repo *inMemoryRepo) CountTags(ctx context.Context) ([]model.TagCount, error) {
+	return countTags(repo.snapshot()), nil
+}
+
+func (repo *inMemoryRepo) snapshot() []*model.Item {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+	items := make([]*model.Item, 0, len(repo.items))
+	for _, item := range repo.items {
+		items = append(items, cloneItem(item))
+	}
+	return items
+}
+
+func (repo *inMemoryRepo) Update(ctx context.Context, item *model.Item) (*model.Item, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	current, ok := repo.items[item.ID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", model.ErrNotFound, item.ID)
+	}
+	if err := checkVersion(current.ID, current.Version, item.Version); err != nil {
+		return nil, err
+	}
+	stored := cloneItem(item)
+	stored.Version++
+	repo.items[item.ID] = stored
+	return cloneItem(stored), nil
+}
+
+func (repo *inMemoryRepo) Patch(ctx context.Context, id string, version int64, fields map[string]interface{}) (*model.Item, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	current, ok := repo.items[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", model.ErrNotFound, id)
+	}
+	if err := checkVersion(current.ID, current.Version, version); err != nil {
+		return nil, err
+	}
+	patched, err := applyFields(current, fields)
+	if err != nil {
+		return nil, err
+	}
+	patched.Version++
+	repo.items[id] = patched
+	return cloneItem(patched), nil
+}
+
+func (repo *inMemoryRepo) DeleteByID(ctx context.Context, id string, version int64) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	current, ok := repo.items[id]
+	if !ok {
+		return fmt.Errorf("%w: %s", model.ErrNotFound, id)
+	}
+	if err := checkVersion(current.ID, current.Version, version); err != nil {
+		return err
+	}
+	delete(repo.items, id)
+	return nil
+}
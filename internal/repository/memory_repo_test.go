@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+)
+
+func TestInMemoryRepo(t *testing.T) {
+	ctx := context.TODO()
+	repo := NewInMemory()
+
+	t.Run("Save and find", func(t *testing.T) {
+		input := &model.Item{Title: "List", Text: "Homework"}
+		saved, err := repo.Save(ctx, input)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, saved.ID)
+		assert.Empty(t, input.ID)
+
+		found, err := repo.FindByID(ctx, saved.ID)
+		assert.Nil(t, err)
+		assert.Equal(t, saved, found)
+
+		found.Title = "Changed"
+		again, _ := repo.FindByID(ctx, saved.ID)
+		assert.Equal(t, "List", again.Title)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		_, err := repo.FindByID(ctx, "missing")
+		assert.True(t, errors.Is(err, model.ErrNotFound))
+		_, err = repo.Update(ctx, &model.Item{ID: "missing"})
+		assert.True(t, errors.Is(err, model.ErrNotFound))
+		_, err = repo.Patch(ctx, "missing", 1, map[string]interface{}{"title": "x"})
+		assert.True(t, errors.Is(err, model.ErrNotFound))
+		assert.True(t, errors.Is(repo.DeleteByID(ctx, "missing", 1), model.ErrNotFound))
+	})
+
+	t.Run("Patch", func(t *testing.T) {
+		saved, _ := repo.Save(ctx, &model.Item{Title: "List", Text: "Homework"})
+		patched, err := repo.Patch(ctx, saved.ID, saved.Version, map[string]interface{}{"title": "Groceries", "done": true})
+		assert.Nil(t, err)
+		assert.Equal(t, &model.Item{ID: saved.ID, Title: "Groceries", Text: "Homework", Done: true, Version: 2}, patched)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		saved, _ := repo.Save(ctx, &model.Item{Title: "List", Text: "Homework"})
+		assert.Nil(t, repo.DeleteByID(ctx, saved.ID, saved.Version))
+		_, err := repo.FindByID(ctx, saved.ID)
+		assert.True(t, errors.Is(err, model.ErrNotFound))
+	})
+}
+
+func TestInMemoryRepoList(t *testing.T) {
+	ctx := context.TODO()
+	repo := NewInMemory()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 25; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, _ = repo.Save(ctx, &model.Item{Title: fmt.Sprintf("item %02d", i), Text: "text", Done: i%5 == 0})
+		}(i)
+	}
+	wg.Wait()
+
+	t.Run("Pages through every item once", func(t *testing.T) {
+		seen := map[string]bool{}
+		query := model.ListQuery{Limit: 10}
+		for {
+			page, err := repo.List(ctx, query)
+			assert.Nil(t, err)
+			for _, item := range page.Items {
+				assert.False(t, seen[item.ID])
+				seen[item.ID] = true
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			query.Cursor = page.NextCursor
+		}
+		assert.Len(t, seen, 25)
+	})
+
+	t.Run("Filters and sorts", func(t *testing.T) {
+		page, err := repo.List(ctx, model.ListQuery{
+			Filter: model.ItemFilter{Status: model.StatusDone},
+			Sort:   model.SortOrder{Field: model.SortByTitle, Descending: true},
+			Limit:  10,
+		})
+		assert.Nil(t, err)
+		titles := make([]string, 0)
+		for _, item := range page.Items {
+			titles = append(titles, item.Title)
+		}
+		assert.Equal(t, []string{"item 20", "item 15", "item 10", "item 05", "item 00"}, titles)
+	})
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed migrations/postgres/*.sql
+var postgresMigrations embed.FS
+
+// migrationLockID serialises concurrent cold starts racing to migrate.
+const migrationLockID = 20210319
+
+type migration struct {
+	version int
+	name    string
+	up      string
+	down    string
+}
+
+// loadMigrations reads NNNN_name.up.sql / NNNN_name.down.sql pairs.
+func loadMigrations(files fs.FS, dir string) ([]migration, error) {
+	entries, err := fs.ReadDir(files, dir)
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[int]*migration{}
+	for _, entry := range entries {
+		name := entry.Name()
+		parts := strings.SplitN(name, "_", 2)
+		version, err := strconv.Atoi(parts[0])
+		if err != nil || len(parts) != 2 {
+			return nil, fmt.Errorf("invalid migration file name %s", name)
+		}
+		content, err := fs.ReadFile(files, path.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		current, ok := byVersion[version]
+		if !ok {
+			current = &migration{version: version}
+			byVersion[version] = current
+		}
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			current.name = strings.TrimSuffix(parts[1], ".up.sql")
+			current.up = string(content)
+		case strings.HasSuffix(name, ".down.sql"):
+			current.down = string(content)
+		default:
+			return nil, fmt.Errorf("invalid migration file name %s", name)
+		}
+	}
+
+	migrations := make([]migration, 0, len(byVersion))
+	for _, current := range byVersion {
+		if current.up == "" || current.down == "" {
+			return nil, fmt.Errorf("migration %04d needs both an up and a down file", current.version)
+		}
+		migrations = append(migrations, *current)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].version < migrations[j].version
+	})
+	return migrations, nil
+}
+
+func migrateUp(ctx context.Context, db *sql.DB, migrations []migration) error {
+	if err := createMigrationsTable(ctx, db); err != nil {
+		return err
+	}
+	for _, current := range migrations {
+		err := inMigrationTx(ctx, db, func(tx *sql.Tx) error {
+			applied, err := isApplied(ctx, tx, current.version)
+			if err != nil || applied {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, current.up); err != nil {
+				return fmt.Errorf("migration %04d_%s: %w", current.version, current.name, err)
+			}
+			_, err = tx.ExecContext(ctx, "INSERT INTO schema_migrations (version) VALUES ($1)", current.version)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func migrateDown(ctx context.Context, db *sql.DB, migrations []migration, steps int) error {
+	if err := createMigrationsTable(ctx, db); err != nil {
+		return err
+	}
+	for i := len(migrations) - 1; i >= 0 && steps > 0; i-- {
+		current := migrations[i]
+		reverted := false
+		err := inMigrationTx(ctx, db, func(tx *sql.Tx) error {
+			applied, err := isApplied(ctx, tx, current.version)
+			if err != nil || !applied {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, current.down); err != nil {
+				return fmt.Errorf("migration %04d_%s: %w", current.version, current.name, err)
+			}
+			reverted = true
+			_, err = tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", current.version)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if reverted {
+			steps--
+		}
+	}
+	return nil
+}
+
+func createMigrationsTable(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`)
+	return err
+}
+
+func inMigrationTx(ctx context.Context, db *sql.DB, apply func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", migrationLockID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := apply(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func isApplied(ctx context.Context, tx *sql.Tx, version int) (bool, error) {
+	var count int
+	err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM schema_migrations WHERE version = $1", version).Scan(&count)
+	return count > 0, err
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	t.Run("Shipped migrations", func(t *testing.T) {
+		migrations, err := loadMigrations(postgresMigrations, "migrations/postgres")
+		assert.Nil(t, err)
+		assert.NotEmpty(t, migrations)
+		for i, current := range migrations {
+			assert.Equal(t, i+1, current.version)
+		}
+	})
+
+	t.Run("Orders by version", func(t *testing.T) {
+		migrations, err := loadMigrations(fstest.MapFS{
+			"m/0002_second.up.sql":   {Data: []byte("up 2")},
+			"m/0002_second.down.sql": {Data: []byte("down 2")},
+			"m/0001_first.up.sql":    {Data: []byte("up 1")},
+			"m/0001_first.down.sql":  {Data: []byte("down 1")},
+		}, "m")
+		assert.Nil(t, err)
+		assert.Equal(t, []migration{
+			{version: 1, name: "first", up: "up 1", down: "down 1"},
+			{version: 2, name: "second", up: "up 2", down: "down 2"},
+		}, migrations)
+	})
+
+	t.Run("Missing down", func(t *testing.T) {
+		_, err := loadMigrations(fstest.MapFS{
+			"m/0001_first.up.sql": {Data: []byte("up 1")},
+		}, "m")
+		assert.NotNil(t, err)
+	})
+}
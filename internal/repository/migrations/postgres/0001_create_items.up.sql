@@ -0,0 +1,10 @@
+CREATE TABLE items (
+    id           TEXT COLLATE "C" PRIMARY KEY,
+    title        TEXT COLLATE "C" NOT NULL,
+    text         TEXT NOT NULL,
+    done         BOOLEAN NOT NULL DEFAULT FALSE,
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX items_done_idx ON items (done, id);
+CREATE INDEX items_title_idx ON items (title, id);
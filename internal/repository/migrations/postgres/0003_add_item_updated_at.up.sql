@@ -0,0 +1 @@
+ALTER TABLE items ADD COLUMN updated_at TIMESTAMPTZ;
@@ -0,0 +1,2 @@
+DROP INDEX items_updated_at_idx;
+ALTER TABLE items DROP COLUMN created_at;
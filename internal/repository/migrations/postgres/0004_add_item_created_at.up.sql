@@ -0,0 +1,4 @@
+ALTER TABLE items ADD COLUMN created_at TIMESTAMPTZ;
+
+CREATE INDEX items_created_at_idx ON items (created_at, id);
+CREATE INDEX items_updated_at_idx ON items (updated_at, id);
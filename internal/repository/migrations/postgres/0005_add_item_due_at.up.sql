@@ -0,0 +1,3 @@
+ALTER TABLE items ADD COLUMN due_at TIMESTAMPTZ;
+
+CREATE INDEX items_open_due_at_idx ON items (due_at, id) WHERE NOT done;
@@ -0,0 +1,3 @@
+ALTER TABLE items ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX items_tags_idx ON items USING GIN (tags);
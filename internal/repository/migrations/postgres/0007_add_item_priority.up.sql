@@ -0,0 +1 @@
+ALTER TABLE items ADD COLUMN priority TEXT NOT NULL DEFAULT '';
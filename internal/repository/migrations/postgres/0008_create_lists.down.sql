@@ -0,0 +1,4 @@
+ALTER TABLE items DROP COLUMN archived;
+ALTER TABLE items DROP COLUMN list_id;
+
+DROP TABLE lists;
@@ -0,0 +1,12 @@
+CREATE TABLE lists (
+    id         TEXT COLLATE "C" PRIMARY KEY,
+    name       TEXT COLLATE "C" NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    version    BIGINT NOT NULL DEFAULT 1
+);
+
+ALTER TABLE items ADD COLUMN list_id TEXT;
+ALTER TABLE items ADD COLUMN archived BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX items_list_id_idx ON items (list_id, id) WHERE list_id IS NOT NULL;
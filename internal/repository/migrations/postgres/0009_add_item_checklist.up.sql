@@ -0,0 +1 @@
+ALTER TABLE items ADD COLUMN checklist JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1 @@
+ALTER TABLE items DROP COLUMN blocked_by;
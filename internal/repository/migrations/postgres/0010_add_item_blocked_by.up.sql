@@ -0,0 +1,3 @@
+ALTER TABLE items ADD COLUMN blocked_by TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX items_blocked_by_idx ON items USING GIN (blocked_by);
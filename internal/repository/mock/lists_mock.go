@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./lists.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockListRepository is a mock of ListRepository interface.
+type MockListRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockListRepositoryMockRecorder
+}
+
+// MockListRepositoryMockRecorder is the mock recorder for MockListRepository.
+type MockListRepositoryMockRecorder struct {
+	mock *MockListRepository
+}
+
+// NewMockListRepository creates a new mock instance.
+func NewMockListRepository(ctrl *gomock.Controller) *MockListRepository {
+	mock := &MockListRepository{ctrl: ctrl}
+	mock.recorder = &MockListRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockListRepository) EXPECT() *MockListRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AllLists mocks base method.
+func (m *MockListRepository) AllLists(ctx context.Context) ([]*model.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllLists", ctx)
+	ret0, _ := ret[0].([]*model.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllLists indicates an expected call of AllLists.
+func (mr *MockListRepositoryMockRecorder) AllLists(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllLists", reflect.TypeOf((*MockListRepository)(nil).AllLists), ctx)
+}
+
+// DeleteListByID mocks base method.
+func (m *MockListRepository) DeleteListByID(ctx context.Context, id string, version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteListByID", ctx, id, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteListByID indicates an expected call of DeleteListByID.
+func (mr *MockListRepositoryMockRecorder) DeleteListByID(ctx, id, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteListByID", reflect.TypeOf((*MockListRepository)(nil).DeleteListByID), ctx, id, version)
+}
+
+// FindListByID mocks base method.
+func (m *MockListRepository) FindListByID(ctx context.Context, id string) (*model.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindListByID", ctx, id)
+	ret0, _ := ret[0].(*model.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindListByID indicates an expected call of FindListByID.
+func (mr *MockListRepositoryMockRecorder) FindListByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindListByID", reflect.TypeOf((*MockListRepository)(nil).FindListByID), ctx, id)
+}
+
+// SaveList mocks base method.
+func (m *MockListRepository) SaveList(ctx context.Context, list *model.List) (*model.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveList", ctx, list)
+	ret0, _ := ret[0].(*model.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveList indicates an expected call of SaveList.
+func (mr *MockListRepositoryMockRecorder) SaveList(ctx, list interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveList", reflect.TypeOf((*MockListRepository)(nil).SaveList), ctx, list)
+}
+
+// UpdateList mocks base method.
+func (m *MockListRepository) UpdateList(ctx context.Context, list *model.List) (*model.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateList", ctx, list)
+	ret0, _ := ret[0].(*model.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateList indicates an expected call of UpdateList.
+func (mr *MockListRepositoryMockRecorder) UpdateList(ctx, list interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateList", reflect.TypeOf((*MockListRepository)(nil).UpdateList), ctx, list)
+}
@@ -5,6 +5,7 @@
 package mock_repository
 
 import (
+	context "context"
 	reflect "reflect"
 
 	model "github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
@@ -34,60 +35,106 @@ func (m *MockTodoRepository) EXPECT() *MockTodoRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountTags mocks base method.
+func (m *MockTodoRepository) CountTags(ctx context.Context) ([]model.TagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTags", ctx)
+	ret0, _ := ret[0].([]model.TagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTags indicates an expected call of CountTags.
+func (mr *MockTodoRepositoryMockRecorder) CountTags(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTags", reflect.TypeOf((*MockTodoRepository)(nil).CountTags), ctx)
+}
+
 // DeleteByID mocks base method.
-func (m *MockTodoRepository) DeleteByID(id string) error {
+func (m *MockTodoRepository) DeleteByID(ctx context.Context, id string, version int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteByID", id)
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteByID indicates an expected call of DeleteByID.
-func (mr *MockTodoRepositoryMockRecorder) DeleteByID(id interface{}) *gomock.Call {
+func (mr *MockTodoRepositoryMockRecorder) DeleteByID(ctx, id, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockTodoRepository)(nil).DeleteByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockTodoRepository)(nil).DeleteByID), ctx, id, version)
 }
 
 // FindByID mocks base method.
-func (m *MockTodoRepository) FindByID(id string) (*model.Item, error) {
+func (m *MockTodoRepository) FindByID(ctx context.Context, id string) (*model.Item, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByID", id)
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
 	ret0, _ := ret[0].(*model.Item)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindByID indicates an expected call of FindByID.
-func (mr *MockTodoRepositoryMockRecorder) FindByID(id interface{}) *gomock.Call {
+func (mr *MockTodoRepositoryMockRecorder) FindByID(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockTodoRepository)(nil).FindByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockTodoRepository)(nil).FindByID), ctx, id)
 }
 
-// ListAll mocks base method.
-func (m *MockTodoRepository) ListAll() ([]*model.Item, error) {
+// List mocks base method.
+func (m *MockTodoRepository) List(ctx context.Context, query model.ListQuery) (*model.Page, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAll")
-	ret0, _ := ret[0].([]*model.Item)
+	ret := m.ctrl.Call(m, "List", ctx, query)
+	ret0, _ := ret[0].(*model.Page)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListAll indicates an expected call of ListAll.
-func (mr *MockTodoRepositoryMockRecorder) ListAll() *gomock.Call {
+// List indicates an expected call of List.
+func (mr *MockTodoRepositoryMockRecorder) List(ctx, query interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockTodoRepository)(nil).ListAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTodoRepository)(nil).List), ctx, query)
+}
+
+// Patch mocks base method.
+func (m *MockTodoRepository) Patch(ctx context.Context, id string, version int64, fields map[string]interface{}) (*model.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", ctx, id, version, fields)
+	ret0, _ := ret[0].(*model.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockTodoRepositoryMockRecorder) Patch(ctx, id, version, fields interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockTodoRepository)(nil).Patch), ctx, id, version, fields)
 }
 
 // Save mocks base method.
-func (m *MockTodoRepository) Save(item *model.Item) error {
+func (m *MockTodoRepository) Save(ctx context.Context, item *model.Item) (*model.Item, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Save", item)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "Save", ctx, item)
+	ret0, _ := ret[0].(*model.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Save indicates an expected call of Save.
-func (mr *MockTodoRepositoryMockRecorder) Save(item interface{}) *gomock.Call {
+func (mr *MockTodoRepositoryMockRecorder) Save(ctx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockTodoRepository)(nil).Save), ctx, item)
+}
+
+// Update mocks base method.
+func (m *MockTodoRepository) Update(ctx context.Context, item *model.Item) (*model.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, item)
+	ret0, _ := ret[0].(*model.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTodoRepositoryMockRecorder) Update(ctx, item interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockTodoRepository)(nil).Save), item)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTodoRepository)(nil).Update), ctx, item)
 }
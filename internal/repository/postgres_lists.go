@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+	"github.com/google/uuid"
+)
+
+const listColumns = "id, name, created_at, updated_at, version"
+
+func (repo *postgresRepo) SaveList(ctx context.Context, list *model.List) (*model.List, error) {
+	stored := cloneList(list)
+	stored.ID = uuid.NewString()
+	stored.Version = 1
+	_, err := repo.db.ExecContext(ctx,
+		"INSERT INTO lists ("+listColumns+") VALUES ($1, $2, $3, $4, $5)",
+		stored.ID, stored.Name, stored.CreatedAt, stored.UpdatedAt, stored.Version)
+	if err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+func (repo *postgresRepo) FindListByID(ctx context.Context, id string) (*model.List, error) {
+	row := repo.db.QueryRowContext(ctx, "SELECT "+listColumns+" FROM lists WHERE id = $1", id)
+	list, err := scanList(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: list %s", model.ErrNotFound, id)
+	}
+	return list, err
+}
+
+func (repo *postgresRepo) AllLists(ctx context.Context) ([]*model.List, error) {
+	rows, err := repo.db.QueryContext(ctx, "SELECT "+listColumns+" FROM lists ORDER BY name, id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	lists := make([]*model.List, 0)
+	for rows.Next() {
+		list, err := scanList(rows)
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, list)
+	}
+	return lists, rows.Err()
+}
+
+func (repo *postgresRepo) UpdateList(ctx context.Context, list *model.List) (*model.List, error) {
+	stored := cloneList(list)
+	stored.Version++
+	result, err := repo.db.ExecContext(ctx,
+		"UPDATE lists SET name = $2, created_at = $3, updated_at = $4, version = $5 WHERE id = $1 AND version = $6",
+		list.ID, list.Name, list.CreatedAt, list.UpdatedAt, stored.Version, list.Version)
+	if err != nil {
+		return nil, err
+	}
+	if err := repo.expectListAffected(ctx, result, list.ID); err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+func (repo *postgresRepo) DeleteListByID(ctx context.Context, id string, version int64) error {
+	result, err := repo.db.ExecContext(ctx, "DELETE FROM lists WHERE id = $1 AND version = $2", id, version)
+	if err != nil {
+		return err
+	}
+	return repo.expectListAffected(ctx, result, id)
+}
+
+func (repo *postgresRepo) expectListAffected(ctx context.Context, result sql.Result, id string) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		return nil
+	}
+	current, err := repo.FindListByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: list %s is at version %d", model.ErrVersionMismatch, id, current.Version)
+}
+
+func scanList(row rowScanner) (*model.List, error) {
+	list := &model.List{}
+	var createdAt, updatedAt sql.NullTime
+	if err := row.Scan(&list.ID, &list.Name, &createdAt, &updatedAt, &list.Version); err != nil {
+		return nil, err
+	}
+	list.CreatedAt = nullableTime(createdAt)
+	list.UpdatedAt = nullableTime(updatedAt)
+	return list, nil
+}
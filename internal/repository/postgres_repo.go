@@ -0,0 +1,407 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const itemColumns = "id, title, text, done, priority, completed_at, due_at, tags, created_at, updated_at, version, list_id, archived, checklist, blocked_by"
+
+// itemAttributes maps the attribute names used in patches and sort orders to
+// their columns.
+var itemAttributes = map[string]string{
+	"title":       "title",
+	"text":        "text",
+	"done":        "done",
+	"priority":    "priority",
+	"completedAt": "completed_at",
+	"dueAt":       "due_at",
+	"tags":        "tags",
+	"listId":      "list_id",
+	"archived":    "archived",
+	"checklist":   "checklist",
+	"blockedBy":   "blocked_by",
+	"createdAt":   "created_at",
+	"updatedAt":   "updated_at",
+}
+
+var sortColumns = map[string]string{
+	model.SortByTitle:     "title",
+	model.SortByCreatedAt: "created_at",
+	model.SortByUpdatedAt: "updated_at",
+	model.SortByDueDate:   "due_at",
+}
+
+type postgresRepo struct {
+	db     *sql.DB
+	cursor cursorSigner
+}
+
+func NewPostgres(dsn string) (*postgresRepo, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &postgresRepo{
+		db:     db,
+		cursor: newCursorSigner(),
+	}, nil
+}
+
+func (repo *postgresRepo) Close() error {
+	return repo.db.Close()
+}
+
+func (repo *postgresRepo) MigrateUp(ctx context.Context) error {
+	migrations, err := loadMigrations(postgresMigrations, "migrations/postgres")
+	if err != nil {
+		return err
+	}
+	return migrateUp(ctx, repo.db, migrations)
+}
+
+func (repo *postgresRepo) MigrateDown(ctx context.Context, steps int) error {
+	migrations, err := loadMigrations(postgresMigrations, "migrations/postgres")
+	if err != nil {
+		return err
+	}
+	return migrateDown(ctx, repo.db, migrations, steps)
+}
+
+func (repo *postgresRepo) Save(ctx context.Context, item *model.Item) (*model.Item, error) {
+	stored := cloneItem(item)
+	stored.ID = uuid.NewString()
+	stored.Version = 1
+	checklist, err := checklistDocument(stored.Checklist)
+	if err != nil {
+		return nil, err
+	}
+	_, err = repo.db.ExecContext(ctx,
+		"INSERT INTO items ("+itemColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)",
+		stored.ID, stored.Title, stored.Text, stored.Done, stored.Priority, stored.CompletedAt, stored.DueAt, textArray(stored.Tags), stored.CreatedAt, stored.UpdatedAt, stored.Version, nullableString(stored.ListID), stored.Archived, checklist, textArray(stored.BlockedBy))
+	if err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+func (repo *postgresRepo) FindByID(ctx context.Context, id string) (*model.Item, error) {
+	row := repo.db.QueryRowContext(ctx, "SELECT "+itemColumns+" FROM items WHERE id = $1", id)
+	item, err := scanItem(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", model.ErrNotFound, id)
+	}
+	return item, err
+}
+
+func (repo *postgresRepo) List(ctx context.Context, query model.ListQuery) (*model.Page, error) {
+	position, err := repo.cursor.decodePosition(query)
+	if err != nil {
+		return nil, err
+	}
+	statement := &sqlStatement{}
+	conditions := filterConditions(query.Filter, statement)
+
+	order := "id ASC"
+	if query.Sort.Field != "" {
+		// Missing timestamps sort as the oldest, like in the other backends.
+		direction, nulls := "ASC", "FIRST"
+		if query.Sort.Descending {
+			direction, nulls = "DESC", "LAST"
+		}
+		order = fmt.Sprintf("%s %s NULLS %s, id %s", sortColumns[query.Sort.Field], direction, nulls, direction)
+	} else if lastID, ok := position.Key["ID"].(string); ok {
+		conditions = append(conditions, "id > "+statement.arg(lastID))
+	}
+
+	sqlQuery := "SELECT " + itemColumns + " FROM items"
+	if len(conditions) > 0 {
+		sqlQuery += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	sqlQuery += " ORDER BY " + order + " LIMIT " + statement.arg(query.Limit+1)
+	if position.Offset > 0 {
+		sqlQuery += " OFFSET " + statement.arg(position.Offset)
+	}
+
+	rows, err := repo.db.QueryContext(ctx, sqlQuery, statement.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	page := &model.Page{
+		Items: make([]*model.Item, 0),
+	}
+	hasMore := false
+	for rows.Next() {
+		if len(page.Items) == query.Limit {
+			hasMore = true
+			break
+		}
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		page.Items = append(page.Items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if hasMore {
+		next := cursorPosition{Offset: position.Offset + query.Limit}
+		if query.Sort.Field == "" {
+			next = cursorPosition{Key: map[string]interface{}{"ID": page.Items[len(page.Items)-1].ID}}
+		}
+		if page.NextCursor, err = repo.cursor.encodePosition(query, next); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+func (repo *postgresRepo) Update(ctx context.Context, item *model.Item) (*model.Item, error) {
+	stored := cloneItem(item)
+	stored.Version++
+	checklist, err := checklistDocument(item.Checklist)
+	if err != nil {
+		return nil, err
+	}
+	result, err := repo.db.ExecContext(ctx,
+		"UPDATE items SET title = $2, text = $3, done = $4, priority = $5, completed_at = $6, due_at = $7, tags = $8, created_at = $9, updated_at = $10, version = $11, list_id = $12, archived = $13, checklist = $14, blocked_by = $15 WHERE id = $1 AND version = $16",
+		item.ID, item.Title, item.Text, item.Done, item.Priority, item.CompletedAt, item.DueAt, textArray(item.Tags), item.CreatedAt, item.UpdatedAt, stored.Version, nullableString(item.ListID), item.Archived, checklist, textArray(item.BlockedBy), item.Version)
+	if err != nil {
+		return nil, err
+	}
+	if err := repo.expectAffected(ctx, result, item.ID); err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+func (repo *postgresRepo) Patch(ctx context.Context, id string, version int64, fields map[string]interface{}) (*model.Item, error) {
+	names := make([]string, 0, len(fields))
+	for field := range fields {
+		if _, ok := itemAttributes[field]; !ok {
+			return nil, fmt.Errorf("%w: unknown field %s", model.ErrValidation, field)
+		}
+		names = append(names, field)
+	}
+	sort.Strings(names)
+
+	statement := &sqlStatement{}
+	idArg := statement.arg(id)
+	versionArg := statement.arg(version)
+	assignments := []string{"version = version + 1"}
+	for _, field := range names {
+		value := fields[field]
+		// A false archived flag is left out of item documents, so patches
+		// remove it; the column is not nullable and stores the false.
+		if field == "archived" && value == nil {
+			value = false
+		}
+		if field == "tags" || field == "blockedBy" {
+			values, ok := value.([]string)
+			if !ok && value != nil {
+				return nil, fmt.Errorf("%w: %s must be a list of strings", model.ErrValidation, field)
+			}
+			value = textArray(values)
+		}
+		if field == "checklist" {
+			entries, ok := value.([]model.ChecklistEntry)
+			if !ok && value != nil {
+				return nil, fmt.Errorf("%w: checklist must be a list of entries", model.ErrValidation)
+			}
+			document, err := checklistDocument(entries)
+			if err != nil {
+				return nil, err
+			}
+			value = document
+		}
+		assignments = append(assignments, itemAttributes[field]+" = "+statement.arg(value))
+	}
+	row := repo.db.QueryRowContext(ctx,
+		"UPDATE items SET "+strings.Join(assignments, ", ")+" WHERE id = "+idArg+" AND version = "+versionArg+" RETURNING "+itemColumns,
+		statement.args...)
+	item, err := scanItem(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.explainMissingRow(ctx, id)
+	}
+	return item, err
+}
+
+func (repo *postgresRepo) DeleteByID(ctx context.Context, id string, version int64) error {
+	result, err := repo.db.ExecContext(ctx, "DELETE FROM items WHERE id = $1 AND version = $2", id, version)
+	if err != nil {
+		return err
+	}
+	return repo.expectAffected(ctx, result, id)
+}
+
+func (repo *postgresRepo) CountTags(ctx context.Context) ([]model.TagCount, error) {
+	rows, err := repo.db.QueryContext(ctx, "SELECT tag, count(*) FROM items, unnest(tags) AS tag GROUP BY tag ORDER BY tag COLLATE \"C\"")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	counts := make([]model.TagCount, 0)
+	for rows.Next() {
+		count := model.TagCount{}
+		if err := rows.Scan(&count.Name, &count.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+	return counts, rows.Err()
+}
+
+type sqlStatement struct {
+	args []interface{}
+}
+
+func (statement *sqlStatement) arg(value interface{}) string {
+	statement.args = append(statement.args, value)
+	return fmt.Sprintf("$%d", len(statement.args))
+}
+
+func filterConditions(filter model.ItemFilter, statement *sqlStatement) []string {
+	conditions := make([]string, 0)
+	switch filter.Status {
+	case model.StatusDone:
+		conditions = append(conditions, "done")
+	case model.StatusOpen:
+		conditions = append(conditions, "NOT done")
+	}
+	if filter.Query != "" {
+		query := statement.arg(filter.Query)
+		conditions = append(conditions, fmt.Sprintf("(strpos(title, %s) > 0 OR strpos(text, %s) > 0)", query, query))
+	}
+	if filter.DueAfter != nil {
+		conditions = append(conditions, "due_at >= "+statement.arg(*filter.DueAfter))
+	}
+	if filter.DueBefore != nil {
+		conditions = append(conditions, "due_at < "+statement.arg(*filter.DueBefore))
+	}
+	if len(filter.Tags) > 0 {
+		operator := "@>"
+		if filter.TagMatch == model.TagMatchAny {
+			operator = "&&"
+		}
+		conditions = append(conditions, "tags "+operator+" "+statement.arg(pq.StringArray(filter.Tags)))
+	}
+	if filter.ListID != "" {
+		conditions = append(conditions, "list_id = "+statement.arg(filter.ListID))
+	}
+	if filter.Archived != nil {
+		conditions = append(conditions, "archived = "+statement.arg(*filter.Archived))
+	}
+	if filter.Blocked != nil {
+		operator := "="
+		if *filter.Blocked {
+			operator = ">"
+		}
+		conditions = append(conditions, "cardinality(blocked_by) "+operator+" 0")
+	}
+	if filter.BlockedBy != "" {
+		conditions = append(conditions, "blocked_by @> "+statement.arg(pq.StringArray{filter.BlockedBy}))
+	}
+	return conditions
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanItem(row rowScanner) (*model.Item, error) {
+	item := &model.Item{}
+	var completedAt, dueAt, createdAt, updatedAt sql.NullTime
+	var tags, blockedBy pq.StringArray
+	var listID sql.NullString
+	var checklist []byte
+	if err := row.Scan(&item.ID, &item.Title, &item.Text, &item.Done, &item.Priority, &completedAt, &dueAt, &tags, &createdAt, &updatedAt, &item.Version, &listID, &item.Archived, &checklist, &blockedBy); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(checklist, &item.Checklist); err != nil {
+		return nil, err
+	}
+	if len(item.Checklist) == 0 {
+		item.Checklist = nil
+	}
+	item.CompletedAt = nullableTime(completedAt)
+	item.DueAt = nullableTime(dueAt)
+	item.ListID = listID.String
+	if len(tags) > 0 {
+		item.Tags = tags
+	}
+	if len(blockedBy) > 0 {
+		item.BlockedBy = blockedBy
+	}
+	item.CreatedAt = nullableTime(createdAt)
+	item.UpdatedAt = nullableTime(updatedAt)
+	return item, nil
+}
+
+// textArray stores a missing list as an empty array, the columns are not
+// nullable.
+func textArray(values []string) pq.StringArray {
+	if values == nil {
+		return pq.StringArray{}
+	}
+	return values
+}
+
+// checklistDocument stores a missing checklist as an empty JSON array, the
+// column is not nullable.
+func checklistDocument(entries []model.ChecklistEntry) (string, error) {
+	if entries == nil {
+		entries = []model.ChecklistEntry{}
+	}
+	document, err := json.Marshal(entries)
+	return string(document), err
+}
+
+func nullableString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+func nullableTime(value sql.NullTime) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	utc := value.Time.UTC()
+	return &utc
+}
+
+func (repo *postgresRepo) expectAffected(ctx context.Context, result sql.Result, id string) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo.explainMissingRow(ctx, id)
+	}
+	return nil
+}
+
+// explainMissingRow tells a missing item from a stale version when a
+// conditional write matched no row.
+func (repo *postgresRepo) explainMissingRow(ctx context.Context, id string) error {
+	current, err := repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: %s is at version %d", model.ErrVersionMismatch, id, current.Version)
+}
@@ -0,0 +1,48 @@
+package repository_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/repository"
+)
+
+type migratingRepo interface {
+	repository.TodoRepository
+	MigrateUp(ctx context.Context) error
+	MigrateDown(ctx context.Context, steps int) error
+	Close() error
+}
+
+// newTestPostgres connects to the database in TODO_POSTGRES_DSN, skipping the
+// test when it is not set, and leaves it with a freshly migrated schema.
+func newTestPostgres(t *testing.T) migratingRepo {
+	dsn := os.Getenv("TODO_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TODO_POSTGRES_DSN is not set")
+	}
+	repo, err := repository.NewPostgres(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+	if err := repo.MigrateDown(ctx, 1000); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.MigrateUp(ctx); err != nil {
+		t.Fatal(err)
+	}
+	return repo
+}
+
+func TestPostgresMigrations(t *testing.T) {
+	ctx := context.TODO()
+	repo := newTestPostgres(t)
+	defer repo.Close()
+
+	assert.Nil(t, repo.MigrateDown(ctx, 1000))
+	assert.Nil(t, repo.MigrateUp(ctx))
+}
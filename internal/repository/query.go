@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+)
+
+// listItems answers a list query over a full set of items, for backends that
+// cannot evaluate filters natively. Unsorted listings page by ID.
+func listItems(items []*model.Item, query model.ListQuery, signer cursorSigner) (*model.Page, error) {
+	position, err := signer.decodePosition(query)
+	if err != nil {
+		return nil, err
+	}
+	matching := make([]*model.Item, 0, len(items))
+	for _, item := range items {
+		if matchesFilter(item, query.Filter) {
+			matching = append(matching, item)
+		}
+	}
+	if query.Sort.Field != "" {
+		sortItems(matching, query.Sort)
+		return pageByOffset(matching, query, position, signer)
+	}
+
+	sort.Slice(matching, func(i, j int) bool {
+		return matching[i].ID < matching[j].ID
+	})
+	if lastID, ok := position.Key["ID"].(string); ok {
+		start := sort.Search(len(matching), func(i int) bool {
+			return matching[i].ID > lastID
+		})
+		matching = matching[start:]
+	}
+	page := &model.Page{
+		Items: make([]*model.Item, 0),
+	}
+	if len(matching) <= query.Limit {
+		page.Items = append(page.Items, matching...)
+		return page, nil
+	}
+	page.Items = append(page.Items, matching[:query.Limit]...)
+	cursor, err := signer.encodePosition(query, cursorPosition{
+		Key: map[string]interface{}{"ID": matching[query.Limit-1].ID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	page.NextCursor = cursor
+	return page, nil
+}
+
+func matchesFilter(item *model.Item, filter model.ItemFilter) bool {
+	switch filter.Status {
+	case model.StatusDone:
+		if !item.Done {
+			return false
+		}
+	case model.StatusOpen:
+		if item.Done {
+			return false
+		}
+	}
+	if filter.Query != "" && !strings.Contains(item.Title, filter.Query) && !strings.Contains(item.Text, filter.Query) {
+		return false
+	}
+	if filter.ListID != "" && item.ListID != filter.ListID {
+		return false
+	}
+	if filter.Archived != nil && item.Archived != *filter.Archived {
+		return false
+	}
+	if filter.Blocked != nil && (len(item.BlockedBy) > 0) != *filter.Blocked {
+		return false
+	}
+	if filter.BlockedBy != "" && !model.ContainsString(item.BlockedBy, filter.BlockedBy) {
+		return false
+	}
+	return matchesDueBounds(item.DueAt, filter) && matchesTags(item.Tags, filter)
+}
+
+func matchesTags(tags []string, filter model.ItemFilter) bool {
+	if len(filter.Tags) == 0 {
+		return true
+	}
+	carried := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		carried[tag] = true
+	}
+	for _, tag := range filter.Tags {
+		if carried[tag] == (filter.TagMatch == model.TagMatchAny) {
+			return carried[tag]
+		}
+	}
+	return filter.TagMatch != model.TagMatchAny
+}
+
+func matchesDueBounds(dueAt *time.Time, filter model.ItemFilter) bool {
+	if filter.DueAfter == nil && filter.DueBefore == nil {
+		return true
+	}
+	if dueAt == nil {
+		return false
+	}
+	if filter.DueAfter != nil && dueAt.Before(*filter.DueAfter) {
+		return false
+	}
+	return filter.DueBefore == nil || dueAt.Before(*filter.DueBefore)
+}
+
+func sortItems(items []*model.Item, order model.SortOrder) {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if order.Descending {
+			a, b = b, a
+		}
+		if comparison := compareItems(a, b, order.Field); comparison != 0 {
+			return comparison < 0
+		}
+		return a.ID < b.ID
+	})
+}
+
+func compareItems(a, b *model.Item, field string) int {
+	switch field {
+	case model.SortByTitle:
+		return strings.Compare(a.Title, b.Title)
+	case model.SortByCreatedAt:
+		return compareTimes(a.CreatedAt, b.CreatedAt)
+	case model.SortByUpdatedAt:
+		return compareTimes(a.UpdatedAt, b.UpdatedAt)
+	case model.SortByDueDate:
+		return compareTimes(a.DueAt, b.DueAt)
+	}
+	return 0
+}
+
+// compareTimes orders missing timestamps first, as the oldest.
+func compareTimes(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	case a.Before(*b):
+		return -1
+	case a.After(*b):
+		return 1
+	}
+	return 0
+}
+
+func countTags(items []*model.Item) []model.TagCount {
+	counts := map[string]int{}
+	for _, item := range items {
+		for _, tag := range item.Tags {
+			counts[tag]++
+		}
+	}
+	result := make([]model.TagCount, 0, len(counts))
+	for name, count := range counts {
+		result = append(result, model.TagCount{Name: name, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+func pageByOffset(items []*model.Item, query model.ListQuery, position cursorPosition, signer cursorSigner) (*model.Page, error) {
+	page := &model.Page{
+		Items: make([]*model.Item, 0),
+	}
+	if position.Offset >= len(items) {
+		return page, nil
+	}
+	end := position.Offset + query.Limit
+	if end > len(items) {
+		end = len(items)
+	}
+	page.Items = append(page.Items, items[position.Offset:end]...)
+	if end < len(items) {
+		cursor, err := signer.encodePosition(query, cursorPosition{Offset: end})
+		if err != nil {
+			return nil, err
+		}
+		page.NextCursor = cursor
+	}
+	return page, nil
+}
+
+func applyFields(item *model.Item, fields map[string]interface{}) (*model.Item, error) {
+	raw, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	document := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &document); err != nil {
+		return nil, err
+	}
+	for field, value := range fields {
+		if value == nil {
+			delete(document, field)
+		} else {
+			document[field] = value
+		}
+	}
+	if raw, err = json.Marshal(document); err != nil {
+		return nil, err
+	}
+	patched := &model.Item{}
+	return patched, json.Unmarshal(raw, patched)
+}
+
+func cloneItem(item *model.Item) *model.Item {
+	clone, _ := applyFields(item, nil)
+	return clone
+}
+
+// checkVersion is the optimistic lock of the backends that compare versions
+// in Go, under their own lock or transaction.
+func checkVersion(id string, current int64, expected int64) error {
+	if current != expected {
+		return fmt.Errorf("%w: %s is at version %d, not %d", model.ErrVersionMismatch, id, current, expected)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+)
+
+func TestSortItems(t *testing.T) {
+	items := []*model.Item{
+		{ID: "3", Title: "b"},
+		{ID: "1", Title: "c"},
+		{ID: "2", Title: "a"},
+		{ID: "0", Title: "b"},
+	}
+
+	sortItems(items, model.SortOrder{Field: model.SortByTitle})
+	assert.Equal(t, []string{"2", "0", "3", "1"}, itemIDs(items))
+
+	sortItems(items, model.SortOrder{Field: model.SortByTitle, Descending: true})
+	assert.Equal(t, []string{"1", "3", "0", "2"}, itemIDs(items))
+}
+
+func TestPageByOffset(t *testing.T) {
+	signer := cursorSigner{secret: []byte("secret")}
+	items := []*model.Item{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+	query := model.ListQuery{Sort: model.SortOrder{Field: model.SortByTitle}, Limit: 2}
+
+	first, err := pageByOffset(items, query, cursorPosition{}, signer)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1", "2"}, itemIDs(first.Items))
+	assert.NotEmpty(t, first.NextCursor)
+
+	query.Cursor = first.NextCursor
+	position, err := signer.decodePosition(query)
+	assert.Nil(t, err)
+
+	second, err := pageByOffset(items, query, position, signer)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"3"}, itemIDs(second.Items))
+	assert.Empty(t, second.NextCursor)
+
+	query.Filter.Status = model.StatusDone
+	_, err = signer.decodePosition(query)
+	assert.NotNil(t, err)
+}
+
+func itemIDs(items []*model.Item) []string {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	return ids
+}
@@ -1,11 +1,30 @@
 package repository
 
-import "github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+import (
+	"context"
 
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+)
+
+// Implementations report missing items with model.ErrNotFound, invalid
+// input with model.ErrValidation and lost races with model.ErrConflict.
+//
+// Every item carries a Version, starting at 1 and incremented by each write.
+// Update, Patch and DeleteByID only apply when the stored version still equals
+// the expected one, item.Version for Update, and fail with
+// model.ErrVersionMismatch otherwise. The services' state changes without a
+// client version, such as completing an item, patch against the version they
+// have just read and report a mismatch as model.ErrConflict instead.
+//
+// CountTags reports how many items carry each tag, ordered by tag name.
+//
 //go:generate mockgen -source=./repo.go -destination=./mock/repo_mock.go
 type TodoRepository interface {
-	Save(item *model.Item) error
-	FindByID(id string) (*model.Item, error)
-	ListAll() ([]*model.Item, error)
-	DeleteByID(id string) error
+	Save(ctx context.Context, item *model.Item) (*model.Item, error)
+	FindByID(ctx context.Context, id string) (*model.Item, error)
+	List(ctx context.Context, query model.ListQuery) (*model.Page, error)
+	Update(ctx context.Context, item *model.Item) (*model.Item, error)
+	Patch(ctx context.Context, id string, version int64, fields map[string]interface{}) (*model.Item, error)
+	DeleteByID(ctx context.Context, id string, version int64) error
+	CountTags(ctx context.Context) ([]model.TagCount, error)
 }
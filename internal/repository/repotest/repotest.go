@@ -0,0 +1,599 @@
+// Package repotest holds the behaviour every TodoRepository has to honour, so
+// each backend can prove it by running the same suite.
+package repotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/BrunoDM2943/go-todo-lambda/internal/constants/model"
+	"github.com/BrunoDM2943/go-todo-lambda/internal/repository"
+)
+
+// Factory returns an empty repository. It is called once per subtest.
+type Factory func(t *testing.T) repository.TodoRepository
+
+func Run(t *testing.T, factory Factory) {
+	t.Run("Save assigns an ID", func(t *testing.T) { testSave(t, factory(t)) })
+	t.Run("Find by ID", func(t *testing.T) { testFindByID(t, factory(t)) })
+	t.Run("Update", func(t *testing.T) { testUpdate(t, factory(t)) })
+	t.Run("Patch", func(t *testing.T) { testPatch(t, factory(t)) })
+	t.Run("Delete", func(t *testing.T) { testDelete(t, factory(t)) })
+	t.Run("Not found", func(t *testing.T) { testNotFound(t, factory(t)) })
+	t.Run("List pages", func(t *testing.T) { testListPages(t, factory(t)) })
+	t.Run("List is stable under writes", func(t *testing.T) { testListStability(t, factory(t)) })
+	t.Run("List filters", func(t *testing.T) { testListFilters(t, factory(t)) })
+	t.Run("List sorts", func(t *testing.T) { testListSorts(t, factory(t)) })
+	t.Run("List sorts by time", func(t *testing.T) { testListSortsByTime(t, factory(t)) })
+	t.Run("List filters by due date", func(t *testing.T) { testListDueDates(t, factory(t)) })
+	t.Run("List filters by tags", func(t *testing.T) { testListTags(t, factory(t)) })
+	t.Run("Count tags", func(t *testing.T) { testCountTags(t, factory(t)) })
+	t.Run("List filters by list and archive", func(t *testing.T) { testListMembership(t, factory(t)) })
+	t.Run("Lists", func(t *testing.T) { testLists(t, factory(t)) })
+	t.Run("Checklists", func(t *testing.T) { testChecklists(t, factory(t)) })
+	t.Run("List filters by dependencies", func(t *testing.T) { testListDependencies(t, factory(t)) })
+	t.Run("List rejects forged cursors", func(t *testing.T) { testForgedCursor(t, factory(t)) })
+	t.Run("Concurrent writes", func(t *testing.T) { testConcurrentWrites(t, factory(t)) })
+	t.Run("Stale versions are rejected", func(t *testing.T) { testStaleVersions(t, factory(t)) })
+	t.Run("Racing writers on one item", func(t *testing.T) { testRacingWriters(t, factory(t)) })
+}
+
+func testSave(t *testing.T, repo repository.TodoRepository) {
+	input := &model.Item{Title: "List", Text: "Homework"}
+	saved, err := repo.Save(context.TODO(), input)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, saved.ID)
+	assert.Equal(t, int64(1), saved.Version)
+	assert.Empty(t, input.ID, "Save must not mutate its argument")
+
+	other, err := repo.Save(context.TODO(), input)
+	assert.Nil(t, err)
+	assert.NotEqual(t, saved.ID, other.ID)
+}
+
+func testFindByID(t *testing.T, repo repository.TodoRepository) {
+	saved := save(t, repo, &model.Item{Title: "List", Text: "Homework", Priority: model.PriorityP1})
+
+	found, err := repo.FindByID(context.TODO(), saved.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, saved, found)
+}
+
+func testUpdate(t *testing.T, repo repository.TodoRepository) {
+	saved := save(t, repo, &model.Item{Title: "List", Text: "Homework"})
+	updatedAt := time.Date(2021, 3, 20, 10, 0, 0, 0, time.UTC)
+	saved.Title = "Groceries"
+	saved.UpdatedAt = &updatedAt
+
+	updated, err := repo.Update(context.TODO(), saved)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), updated.Version)
+	assert.Equal(t, int64(1), saved.Version, "Update must not mutate its argument")
+	found := find(t, repo, saved.ID)
+	assert.Equal(t, updated, found)
+	assert.Equal(t, "Groceries", found.Title)
+	assert.Equal(t, "Homework", found.Text)
+	if assert.NotNil(t, found.UpdatedAt) {
+		assert.True(t, updatedAt.Equal(*found.UpdatedAt))
+	}
+}
+
+func testPatch(t *testing.T, repo repository.TodoRepository) {
+	saved := save(t, repo, &model.Item{Title: "List", Text: "Homework"})
+	completedAt := time.Date(2021, 3, 20, 10, 0, 0, 0, time.UTC)
+
+	patched, err := repo.Patch(context.TODO(), saved.ID, saved.Version, map[string]interface{}{
+		"title":       "Groceries",
+		"priority":    model.PriorityP0,
+		"done":        true,
+		"completedAt": completedAt,
+		"updatedAt":   completedAt,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "Groceries", patched.Title)
+	assert.Equal(t, "Homework", patched.Text, "untouched attributes are kept")
+	assert.True(t, patched.Done)
+	assert.Equal(t, model.PriorityP0, patched.Priority)
+	assert.Equal(t, int64(2), patched.Version)
+	if assert.NotNil(t, patched.CompletedAt) {
+		assert.True(t, completedAt.Equal(*patched.CompletedAt))
+	}
+	if assert.NotNil(t, patched.UpdatedAt) {
+		assert.True(t, completedAt.Equal(*patched.UpdatedAt))
+	}
+	assert.Equal(t, patched, find(t, repo, saved.ID))
+
+	patched, err = repo.Patch(context.TODO(), saved.ID, patched.Version, map[string]interface{}{
+		"done":        false,
+		"completedAt": nil,
+	})
+	assert.Nil(t, err)
+	assert.False(t, patched.Done)
+	assert.Nil(t, patched.CompletedAt, "nil removes the attribute")
+}
+
+func testDelete(t *testing.T, repo repository.TodoRepository) {
+	saved := save(t, repo, &model.Item{Title: "List", Text: "Homework"})
+
+	assert.Nil(t, repo.DeleteByID(context.TODO(), saved.ID, saved.Version))
+	_, err := repo.FindByID(context.TODO(), saved.ID)
+	assert.True(t, errors.Is(err, model.ErrNotFound))
+}
+
+func testNotFound(t *testing.T, repo repository.TodoRepository) {
+	ctx := context.TODO()
+	_, err := repo.FindByID(ctx, "missing")
+	assert.True(t, errors.Is(err, model.ErrNotFound), "FindByID: %v", err)
+	_, err = repo.Update(ctx, &model.Item{ID: "missing", Title: "List", Text: "Homework", Version: 1})
+	assert.True(t, errors.Is(err, model.ErrNotFound), "Update: %v", err)
+	_, err = repo.Patch(ctx, "missing", 1, map[string]interface{}{"title": "Groceries"})
+	assert.True(t, errors.Is(err, model.ErrNotFound), "Patch: %v", err)
+	err = repo.DeleteByID(ctx, "missing", 1)
+	assert.True(t, errors.Is(err, model.ErrNotFound), "DeleteByID: %v", err)
+
+	page, err := repo.List(ctx, model.ListQuery{Limit: 10})
+	assert.Nil(t, err)
+	assert.Empty(t, page.Items, "failed writes must not create items")
+}
+
+func testListPages(t *testing.T, repo repository.TodoRepository) {
+	expected := map[string]bool{}
+	for i := 0; i < 7; i++ {
+		expected[save(t, repo, &model.Item{Title: fmt.Sprintf("item %d", i), Text: "text"}).ID] = true
+	}
+
+	seen := listAll(t, repo, model.ListQuery{Limit: 3})
+	assert.Len(t, seen, len(expected))
+	for _, item := range seen {
+		assert.True(t, expected[item.ID])
+	}
+}
+
+func testListStability(t *testing.T, repo repository.TodoRepository) {
+	existing := map[string]bool{}
+	for i := 0; i < 6; i++ {
+		existing[save(t, repo, &model.Item{Title: fmt.Sprintf("item %d", i), Text: "text"}).ID] = true
+	}
+
+	seen := map[string]int{}
+	query := model.ListQuery{Limit: 2}
+	for {
+		page, err := repo.List(context.TODO(), query)
+		if !assert.Nil(t, err) {
+			return
+		}
+		for _, item := range page.Items {
+			seen[item.ID]++
+		}
+		save(t, repo, &model.Item{Title: "added while paging", Text: "text"})
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+
+	for id := range existing {
+		assert.Equal(t, 1, seen[id], "item %s must be listed exactly once", id)
+	}
+	for id, count := range seen {
+		assert.Equal(t, 1, count, "item %s was listed more than once", id)
+	}
+}
+
+func testListFilters(t *testing.T, repo repository.TodoRepository) {
+	open := save(t, repo, &model.Item{Title: "Groceries", Text: "Milk and eggs"})
+	done := save(t, repo, &model.Item{Title: "Homework", Text: "Maths"})
+	_, err := repo.Patch(context.TODO(), done.ID, done.Version, map[string]interface{}{"done": true})
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{open.ID}, ids(listAll(t, repo, model.ListQuery{
+		Filter: model.ItemFilter{Status: model.StatusOpen},
+		Limit:  1,
+	})))
+	assert.Equal(t, []string{done.ID}, ids(listAll(t, repo, model.ListQuery{
+		Filter: model.ItemFilter{Status: model.StatusDone},
+		Limit:  1,
+	})))
+	assert.Equal(t, []string{open.ID}, ids(listAll(t, repo, model.ListQuery{
+		Filter: model.ItemFilter{Query: "eggs"},
+		Limit:  1,
+	})))
+	assert.Empty(t, listAll(t, repo, model.ListQuery{
+		Filter: model.ItemFilter{Status: model.StatusDone, Query: "eggs"},
+		Limit:  1,
+	}))
+}
+
+func testListSorts(t *testing.T, repo repository.TodoRepository) {
+	for _, title := range []string{"b", "d", "a", "c", "e"} {
+		save(t, repo, &model.Item{Title: title, Text: "text"})
+	}
+
+	ascending := listAll(t, repo, model.ListQuery{Sort: model.SortOrder{Field: model.SortByTitle}, Limit: 2})
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, titles(ascending))
+
+	descending := listAll(t, repo, model.ListQuery{Sort: model.SortOrder{Field: model.SortByTitle, Descending: true}, Limit: 2})
+	assert.Equal(t, []string{"e", "d", "c", "b", "a"}, titles(descending))
+}
+
+func testListSortsByTime(t *testing.T, repo repository.TodoRepository) {
+	base := time.Date(2021, 3, 20, 10, 0, 0, 0, time.UTC)
+	for title, minutes := range map[string]time.Duration{"second": 2, "first": 1, "third": 3} {
+		stamp := base.Add(minutes * time.Minute)
+		save(t, repo, &model.Item{Title: title, Text: "text", CreatedAt: &stamp, UpdatedAt: &stamp})
+	}
+	save(t, repo, &model.Item{Title: "undated", Text: "text"})
+
+	ascending := listAll(t, repo, model.ListQuery{Sort: model.SortOrder{Field: model.SortByCreatedAt}, Limit: 3})
+	assert.Equal(t, []string{"undated", "first", "second", "third"}, titles(ascending), "missing timestamps sort first")
+
+	descending := listAll(t, repo, model.ListQuery{Sort: model.SortOrder{Field: model.SortByUpdatedAt, Descending: true}, Limit: 3})
+	assert.Equal(t, []string{"third", "second", "first", "undated"}, titles(descending))
+}
+
+func testListDueDates(t *testing.T, repo repository.TodoRepository) {
+	base := time.Date(2021, 3, 20, 10, 0, 0, 0, time.UTC)
+	at := func(hours time.Duration) *time.Time {
+		due := base.Add(hours * time.Hour)
+		return &due
+	}
+	save(t, repo, &model.Item{Title: "first", Text: "text", DueAt: at(1)})
+	save(t, repo, &model.Item{Title: "second", Text: "text", DueAt: at(2), Done: true})
+	third := save(t, repo, &model.Item{Title: "third", Text: "text", DueAt: at(3)})
+	save(t, repo, &model.Item{Title: "undated", Text: "text"})
+
+	byDueDate := model.SortOrder{Field: model.SortByDueDate}
+	window := listAll(t, repo, model.ListQuery{
+		Filter: model.ItemFilter{DueAfter: at(1), DueBefore: at(3)},
+		Sort:   byDueDate,
+		Limit:  1,
+	})
+	assert.Equal(t, []string{"first", "second"}, titles(window), "DueAfter is inclusive, DueBefore exclusive")
+
+	overdue := listAll(t, repo, model.ListQuery{
+		Filter: model.ItemFilter{Status: model.StatusOpen, DueBefore: at(4)},
+		Sort:   byDueDate,
+		Limit:  10,
+	})
+	assert.Equal(t, []string{"first", "third"}, titles(overdue))
+
+	latest := listAll(t, repo, model.ListQuery{
+		Filter: model.ItemFilter{DueAfter: at(1)},
+		Sort:   model.SortOrder{Field: model.SortByDueDate, Descending: true},
+		Limit:  2,
+	})
+	assert.Equal(t, []string{"third", "second", "first"}, titles(latest))
+
+	byTitle := listAll(t, repo, model.ListQuery{
+		Filter: model.ItemFilter{DueBefore: at(4)},
+		Sort:   model.SortOrder{Field: model.SortByTitle, Descending: true},
+		Limit:  2,
+	})
+	assert.Equal(t, []string{"third", "second", "first"}, titles(byTitle))
+
+	_, err := repo.Patch(context.TODO(), third.ID, third.Version, map[string]interface{}{"dueAt": nil})
+	assert.Nil(t, err)
+	remaining := listAll(t, repo, model.ListQuery{Filter: model.ItemFilter{DueAfter: at(0)}, Limit: 10})
+	assert.ElementsMatch(t, []string{"first", "second"}, titles(remaining), "removing the due date leaves the window")
+
+	moved, err := repo.Patch(context.TODO(), third.ID, third.Version+1, map[string]interface{}{"dueAt": *at(5)})
+	assert.Nil(t, err)
+	if assert.NotNil(t, moved.DueAt) {
+		assert.True(t, at(5).Equal(*moved.DueAt))
+	}
+	later := listAll(t, repo, model.ListQuery{Filter: model.ItemFilter{DueAfter: at(4)}, Limit: 10})
+	assert.Equal(t, []string{"third"}, titles(later))
+}
+
+func testListTags(t *testing.T, repo repository.TodoRepository) {
+	save(t, repo, &model.Item{Title: "report", Text: "text", Tags: []string{"urgent", "work"}})
+	save(t, repo, &model.Item{Title: "slides", Text: "text", Tags: []string{"work"}})
+	laundry := save(t, repo, &model.Item{Title: "laundry", Text: "text", Tags: []string{"home"}})
+	save(t, repo, &model.Item{Title: "untagged", Text: "text"})
+
+	byTitle := model.SortOrder{Field: model.SortByTitle}
+	all := listAll(t, repo, model.ListQuery{Filter: model.ItemFilter{Tags: []string{"work", "urgent"}}, Sort: byTitle, Limit: 10})
+	assert.Equal(t, []string{"report"}, titles(all), "items must carry every tag")
+
+	either := listAll(t, repo, model.ListQuery{Filter: model.ItemFilter{Tags: []string{"home", "urgent"}, TagMatch: model.TagMatchAny}, Sort: byTitle, Limit: 1})
+	assert.Equal(t, []string{"laundry", "report"}, titles(either), "items must carry one of the tags")
+
+	found := find(t, repo, laundry.ID)
+	assert.Equal(t, []string{"home"}, found.Tags)
+
+	patched, err := repo.Patch(context.TODO(), laundry.ID, laundry.Version, map[string]interface{}{"tags": []string{"errands", "home"}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"errands", "home"}, patched.Tags)
+	errands := listAll(t, repo, model.ListQuery{Filter: model.ItemFilter{Tags: []string{"errands"}}, Limit: 10})
+	assert.Equal(t, []string{"laundry"}, titles(errands))
+
+	patched, err = repo.Patch(context.TODO(), laundry.ID, patched.Version, map[string]interface{}{"tags": nil})
+	assert.Nil(t, err)
+	assert.Empty(t, patched.Tags)
+	assert.Empty(t, listAll(t, repo, model.ListQuery{Filter: model.ItemFilter{Tags: []string{"home"}}, Limit: 10}))
+}
+
+func testCountTags(t *testing.T, repo repository.TodoRepository) {
+	counts, err := repo.CountTags(context.TODO())
+	assert.Nil(t, err)
+	assert.Empty(t, counts)
+
+	save(t, repo, &model.Item{Title: "report", Text: "text", Tags: []string{"urgent", "work"}})
+	save(t, repo, &model.Item{Title: "slides", Text: "text", Tags: []string{"work"}})
+	save(t, repo, &model.Item{Title: "untagged", Text: "text"})
+
+	counts, err = repo.CountTags(context.TODO())
+	assert.Nil(t, err)
+	assert.Equal(t, []model.TagCount{{Name: "urgent", Count: 1}, {Name: "work", Count: 2}}, counts)
+}
+
+func testListMembership(t *testing.T, repo repository.TodoRepository) {
+	now := time.Date(2021, 3, 20, 12, 0, 0, 0, time.UTC)
+	soon := now.Add(time.Hour)
+	save(t, repo, &model.Item{Title: "report", Text: "text", ListID: "work", Tags: []string{"urgent"}})
+	save(t, repo, &model.Item{Title: "slides", Text: "text", ListID: "work", DueAt: &soon})
+	save(t, repo, &model.Item{Title: "laundry", Text: "text", ListID: "home"})
+	old := save(t, repo, &model.Item{Title: "old", Text: "text", Archived: true})
+	save(t, repo, &model.Item{Title: "loose", Text: "text"})
+
+	byTitle := model.SortOrder{Field: model.SortByTitle}
+	work := listAll(t, repo, model.ListQuery{Filter: model.ItemFilter{ListID: "work"}, Limit: 1})
+	assert.ElementsMatch(t, []string{"report", "slides"}, titles(work))
+	sorted := listAll(t, repo, model.ListQuery{Filter: model.ItemFilter{ListID: "work"}, Sort: byTitle, Limit: 1})
+	assert.Equal(t, []string{"report", "slides"}, titles(sorted))
+	urgent := listAll(t, repo, model.ListQuery{Filter: model.ItemFilter{ListID: "work", Tags: []string{"urgent"}}, Limit: 10})
+	assert.Equal(t, []string{"report"}, titles(urgent))
+	due := listAll(t, repo, model.ListQuery{Filter: model.ItemFilter{ListID: "work", DueBefore: &soon}, Limit: 10})
+	assert.Empty(t, due, "the due bound is exclusive")
+	due = listAll(t, repo, model.ListQuery{Filter: model.ItemFilter{ListID: "work", DueAfter: &now}, Limit: 10})
+	assert.Equal(t, []string{"slides"}, titles(due))
+
+	archived, active := true, false
+	hidden := listAll(t, repo, model.ListQuery{Filter: model.ItemFilter{Archived: &archived}, Limit: 10})
+	assert.Equal(t, []string{"old"}, titles(hidden))
+	shown := listAll(t, repo, model.ListQuery{Filter: model.ItemFilter{Archived: &active}, Sort: byTitle, Limit: 10})
+	assert.Equal(t, []string{"laundry", "loose", "report", "slides"}, titles(shown))
+
+	moved, err := repo.Patch(context.TODO(), old.ID, old.Version, map[string]interface{}{"archived": nil, "listId": "home"})
+	assert.Nil(t, err)
+	assert.Equal(t, "home", moved.ListID)
+	assert.False(t, moved.Archived)
+	home := listAll(t, repo, model.ListQuery{Filter: model.ItemFilter{ListID: "home"}, Sort: byTitle, Limit: 10})
+	assert.Equal(t, []string{"laundry", "old"}, titles(home))
+
+	moved, err = repo.Patch(context.TODO(), old.ID, moved.Version, map[string]interface{}{"listId": nil})
+	assert.Nil(t, err)
+	assert.Empty(t, moved.ListID)
+	home = listAll(t, repo, model.ListQuery{Filter: model.ItemFilter{ListID: "home"}, Limit: 10})
+	assert.Equal(t, []string{"laundry"}, titles(home))
+}
+
+func testLists(t *testing.T, todos repository.TodoRepository) {
+	repo, ok := todos.(repository.ListRepository)
+	if !ok {
+		t.Skip("the backend does not store lists")
+	}
+	ctx := context.TODO()
+	lists, err := repo.AllLists(ctx)
+	assert.Nil(t, err)
+	assert.Empty(t, lists)
+
+	work, err := repo.SaveList(ctx, &model.List{Name: "Work"})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, work.ID)
+	assert.Equal(t, int64(1), work.Version)
+	home, err := repo.SaveList(ctx, &model.List{Name: "Home"})
+	assert.Nil(t, err)
+
+	found, err := repo.FindListByID(ctx, work.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, work, found)
+	lists, err = repo.AllLists(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.List{home, work}, lists, "lists are ordered by name")
+
+	renamed := *work
+	renamed.Name = "Office"
+	updated, err := repo.UpdateList(ctx, &renamed)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), updated.Version)
+	_, err = repo.UpdateList(ctx, &renamed)
+	assert.True(t, errors.Is(err, model.ErrVersionMismatch), "UpdateList: %v", err)
+	err = repo.DeleteListByID(ctx, work.ID, work.Version)
+	assert.True(t, errors.Is(err, model.ErrVersionMismatch), "DeleteListByID: %v", err)
+
+	assert.Nil(t, repo.DeleteListByID(ctx, work.ID, updated.Version))
+	_, err = repo.FindListByID(ctx, work.ID)
+	assert.True(t, errors.Is(err, model.ErrNotFound), "FindListByID: %v", err)
+	err = repo.DeleteListByID(ctx, work.ID, updated.Version)
+	assert.True(t, errors.Is(err, model.ErrNotFound), "DeleteListByID: %v", err)
+	_, err = repo.UpdateList(ctx, updated)
+	assert.True(t, errors.Is(err, model.ErrNotFound), "UpdateList: %v", err)
+}
+
+func testChecklists(t *testing.T, repo repository.TodoRepository) {
+	ctx := context.TODO()
+	entries := []model.ChecklistEntry{
+		{ID: "a", Text: "outline", Done: true, Position: 0},
+		{ID: "b", Text: "draft", Position: 1},
+	}
+	saved := save(t, repo, &model.Item{Title: "report", Text: "text", Checklist: entries})
+	assert.Equal(t, entries, find(t, repo, saved.ID).Checklist)
+
+	entries = append(entries, model.ChecklistEntry{ID: "c", Text: "review", Position: 2})
+	patched, err := repo.Patch(ctx, saved.ID, saved.Version, map[string]interface{}{"checklist": entries})
+	assert.Nil(t, err)
+	assert.Equal(t, entries, patched.Checklist)
+	assert.Equal(t, entries, find(t, repo, saved.ID).Checklist)
+
+	patched, err = repo.Patch(ctx, saved.ID, patched.Version, map[string]interface{}{"checklist": nil})
+	assert.Nil(t, err)
+	assert.Empty(t, patched.Checklist)
+	assert.Empty(t, find(t, repo, saved.ID).Checklist)
+}
+
+func testListDependencies(t *testing.T, repo repository.TodoRepository) {
+	design := save(t, repo, &model.Item{Title: "design", Text: "text"})
+	build := save(t, repo, &model.Item{Title: "build", Text: "text", BlockedBy: []string{design.ID}})
+	save(t, repo, &model.Item{Title: "ship", Text: "text", BlockedBy: []string{build.ID, design.ID}})
+
+	byTitle := model.SortOrder{Field: model.SortByTitle}
+	blocked, unblocked := true, false
+	waiting := listAll(t, repo, model.ListQuery{Filter: model.ItemFilter{Blocked: &blocked}, Sort: byTitle, Limit: 10})
+	assert.Equal(t, []string{"build", "ship"}, titles(waiting))
+	free := listAll(t, repo, model.ListQuery{Filter: model.ItemFilter{Blocked: &unblocked}, Limit: 10})
+	assert.Equal(t, []string{"design"}, titles(free))
+	dependents := listAll(t, repo, model.ListQuery{Filter: model.ItemFilter{BlockedBy: design.ID}, Sort: byTitle, Limit: 1})
+	assert.Equal(t, []string{"build", "ship"}, titles(dependents))
+
+	patched, err := repo.Patch(context.TODO(), build.ID, build.Version, map[string]interface{}{"blockedBy": nil})
+	assert.Nil(t, err)
+	assert.Empty(t, patched.BlockedBy)
+	free = listAll(t, repo, model.ListQuery{Filter: model.ItemFilter{Blocked: &unblocked}, Sort: byTitle, Limit: 10})
+	assert.Equal(t, []string{"build", "design"}, titles(free))
+	dependents = listAll(t, repo, model.ListQuery{Filter: model.ItemFilter{BlockedBy: build.ID}, Limit: 10})
+	assert.Equal(t, []string{"ship"}, titles(dependents))
+	assert.ElementsMatch(t, []string{build.ID, design.ID}, dependents[0].BlockedBy)
+}
+
+func testForgedCursor(t *testing.T, repo repository.TodoRepository) {
+	_, err := repo.List(context.TODO(), model.ListQuery{Limit: 10, Cursor: "eyJxIjoiIn0.forged"})
+	assert.True(t, errors.Is(err, model.ErrValidation), "%v", err)
+}
+
+func testConcurrentWrites(t *testing.T, repo repository.TodoRepository) {
+	const writers = 20
+	var wg sync.WaitGroup
+	saved := make(chan string, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			item, err := repo.Save(context.TODO(), &model.Item{Title: fmt.Sprintf("item %d", i), Text: "text"})
+			if assert.Nil(t, err) {
+				saved <- item.ID
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(saved)
+
+	for id := range saved {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			_, err := repo.Patch(context.TODO(), id, 1, map[string]interface{}{"done": true})
+			assert.Nil(t, err)
+		}(id)
+	}
+	wg.Wait()
+
+	done := listAll(t, repo, model.ListQuery{Filter: model.ItemFilter{Status: model.StatusDone}, Limit: 7})
+	assert.Len(t, done, writers)
+}
+
+func testStaleVersions(t *testing.T, repo repository.TodoRepository) {
+	ctx := context.TODO()
+	saved := save(t, repo, &model.Item{Title: "List", Text: "Homework"})
+	current, err := repo.Patch(ctx, saved.ID, saved.Version, map[string]interface{}{"title": "Groceries"})
+	assert.Nil(t, err)
+
+	stale := *saved
+	stale.Text = "Overwritten"
+	_, err = repo.Update(ctx, &stale)
+	assert.True(t, errors.Is(err, model.ErrVersionMismatch), "Update: %v", err)
+	_, err = repo.Patch(ctx, saved.ID, saved.Version, map[string]interface{}{"text": "Overwritten"})
+	assert.True(t, errors.Is(err, model.ErrVersionMismatch), "Patch: %v", err)
+	err = repo.DeleteByID(ctx, saved.ID, saved.Version)
+	assert.True(t, errors.Is(err, model.ErrVersionMismatch), "DeleteByID: %v", err)
+
+	assert.Equal(t, current, find(t, repo, saved.ID), "rejected writes must not change the item")
+}
+
+func testRacingWriters(t *testing.T, repo repository.TodoRepository) {
+	const writers = 10
+	saved := save(t, repo, &model.Item{Title: "List", Text: "Homework"})
+
+	var wg sync.WaitGroup
+	results := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := repo.Patch(context.TODO(), saved.ID, saved.Version, map[string]interface{}{"text": fmt.Sprintf("writer %d", i)})
+			results <- err
+		}(i)
+	}
+	wg.Wait()
+	close(results)
+
+	succeeded := 0
+	for err := range results {
+		if err == nil {
+			succeeded++
+		} else {
+			assert.True(t, errors.Is(err, model.ErrVersionMismatch), "%v", err)
+		}
+	}
+	assert.Equal(t, 1, succeeded, "exactly one writer may win")
+	assert.Equal(t, saved.Version+1, find(t, repo, saved.ID).Version)
+}
+
+func save(t *testing.T, repo repository.TodoRepository, item *model.Item) *model.Item {
+	t.Helper()
+	saved, err := repo.Save(context.TODO(), item)
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	return saved
+}
+
+func find(t *testing.T, repo repository.TodoRepository, id string) *model.Item {
+	t.Helper()
+	item, err := repo.FindByID(context.TODO(), id)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	return item
+}
+
+func listAll(t *testing.T, repo repository.TodoRepository, query model.ListQuery) []*model.Item {
+	t.Helper()
+	items := make([]*model.Item, 0)
+	for {
+		page, err := repo.List(context.TODO(), query)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(page.Items) > query.Limit {
+			t.Fatalf("List returned %d items for a limit of %d", len(page.Items), query.Limit)
+		}
+		items = append(items, page.Items...)
+		if page.NextCursor == "" {
+			return items
+		}
+		query.Cursor = page.NextCursor
+	}
+}
+
+func ids(items []*model.Item) []string {
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		result = append(result, item.ID)
+	}
+	return result
+}
+
+func titles(items []*model.Item) []string {
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		result = append(result, item.Title)
+	}
+	return result
+}
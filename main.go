@@ -1,7 +1,19 @@
 package main
 
-import "github.com/BrunoDM2943/go-todo-lambda/cmd/aws"
+import (
+	"flag"
+
+	"github.com/BrunoDM2943/go-todo-lambda/cmd/aws"
+	"github.com/BrunoDM2943/go-todo-lambda/cmd/local"
+)
 
 func main() {
+	address := flag.String("local", "", "serve the API over HTTP on this address instead of starting the Lambda runtime")
+	flag.Parse()
+
+	if *address != "" {
+		local.StartServer(*address)
+		return
+	}
 	aws.StartLambda()
 }